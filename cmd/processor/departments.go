@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/seu-usuario/worker-pool-csv-processor/internal/database"
+)
+
+const departmentsUsage = `Uso: processor departments [-db caminho] <ação>
+
+Ações:
+  list            Lista os departamentos cadastrados
+  add <nome>      Cadastra um departamento (ou reativa um desativado)
+  retire <nome>   Desativa um departamento; funcionários já importados são mantidos
+`
+
+// runDepartments executa o subcomando "departments", que mantém o cadastro de
+// departamentos usado na validação
+func runDepartments(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("departments", flag.ContinueOnError)
+	fs.SetOutput(out)
+	dbPath := fs.String("db", "employees.db", "Caminho do banco de dados SQLite")
+	fs.Usage = func() {
+		fmt.Fprint(out, departmentsUsage)
+		fmt.Fprintln(out, "\nOpções:")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	action, names := fs.Arg(0), fs.Args()
+	if len(names) > 0 {
+		names = names[1:]
+	}
+	switch {
+	case action == "list" && len(names) == 0:
+	case (action == "add" || action == "retire") && len(names) == 1:
+	default:
+		fs.Usage()
+		return fmt.Errorf("ação inválida para departments: %q", fs.Args())
+	}
+
+	db, err := database.NewDB(*dbPath)
+	if err != nil {
+		return fmt.Errorf("erro ao conectar ao banco: %w", err)
+	}
+	defer db.Close()
+
+	switch action {
+	case "add":
+		department, err := db.AddDepartment(names[0])
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "✅ Departamento %s ativo (id %d)\n", department.Name, department.ID)
+
+	case "retire":
+		if err := db.RetireDepartment(names[0]); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "✅ Departamento %s desativado\n", names[0])
+
+	default:
+		departments, err := db.ListDepartments()
+		if err != nil {
+			return err
+		}
+		for _, department := range departments {
+			status := "ativo"
+			if !department.Active {
+				status = "desativado em " + department.RetiredAt.Format("2006-01-02")
+			}
+			fmt.Fprintf(out, "%4d  %-20s %s\n", department.ID, department.Name, status)
+		}
+	}
+	return nil
+}
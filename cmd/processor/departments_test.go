@@ -0,0 +1,41 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunDepartments(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	run := func(args ...string) (string, error) {
+		var out bytes.Buffer
+		err := runDepartments(append([]string{"-db", dbPath}, args...), &out)
+		return out.String(), err
+	}
+
+	if out, err := run("add", "Logística"); err != nil || !strings.Contains(out, "Logística ativo") {
+		t.Fatalf("Expected department added, got %q (err %v)", out, err)
+	}
+	if out, err := run("retire", "TI"); err != nil || !strings.Contains(out, "TI desativado") {
+		t.Fatalf("Expected department retired, got %q (err %v)", out, err)
+	}
+
+	out, err := run("list")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.Contains(out, "Logística") || !strings.Contains(out, "desativado em") {
+		t.Errorf("Expected new and retired departments in list, got %q", out)
+	}
+
+	if _, err := run("retire", "Inexistente"); err == nil {
+		t.Error("Expected error retiring unknown department, got nil")
+	}
+	for _, args := range [][]string{{}, {"add"}, {"remove", "TI"}, {"list", "extra"}} {
+		if _, err := run(args...); err == nil {
+			t.Errorf("Expected usage error for %v, got nil", args)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/seu-usuario/worker-pool-csv-processor/internal/csvreader"
+	"github.com/seu-usuario/worker-pool-csv-processor/internal/input"
+	"github.com/seu-usuario/worker-pool-csv-processor/internal/jsonreader"
+	"github.com/seu-usuario/worker-pool-csv-processor/internal/models"
+	"github.com/seu-usuario/worker-pool-csv-processor/internal/xlsxreader"
+)
+
+// Formatos de entrada aceitos pela flag -format
+const (
+	formatAuto  = "auto"
+	formatCSV   = "csv"
+	formatJSONL = "jsonl"
+	formatJSON  = "json"
+	formatXLSX  = "xlsx"
+)
+
+// inputFormat retorna o formato de entrada, deduzido da extensão do arquivo com -format auto.
+// Extensões de compressão são ignoradas (employees.csv.gz é CSV).
+func (cfg config) inputFormat() string {
+	if cfg.explicitFormat() {
+		return strings.ToLower(cfg.format)
+	}
+	if format := formatFromName(cfg.csvFile); format != "" {
+		return format
+	}
+	return formatCSV
+}
+
+// explicitFormat indica se o formato foi informado em -format
+func (cfg config) explicitFormat() bool {
+	return cfg.format != "" && !strings.EqualFold(cfg.format, formatAuto)
+}
+
+// formatFromName deduz o formato pela extensão; retorna "" se ela não for reconhecida
+func formatFromName(name string) string {
+	switch strings.ToLower(filepath.Ext(input.TrimCompressionExt(name))) {
+	case ".csv", ".tsv", ".txt":
+		return formatCSV
+	case ".jsonl", ".ndjson":
+		return formatJSONL
+	case ".json":
+		return formatJSON
+	case ".xlsx":
+		return formatXLSX
+	default:
+		return ""
+	}
+}
+
+// archiveFormatFromName deduz o formato de um item de zip. Arquivos .txt
+// costumam ser instruções (LEIAME.txt) e não são lidos como CSV.
+func archiveFormatFromName(name string) string {
+	if strings.EqualFold(filepath.Ext(input.TrimCompressionExt(name)), ".txt") {
+		return ""
+	}
+	return formatFromName(name)
+}
+
+// newRecordReader cria o leitor adequado ao formato do arquivo de entrada.
+// Em um zip, cada arquivo contido é lido em sequência; com -format auto,
+// arquivos de extensão desconhecida ou .txt são ignorados, e um arquivo que
+// não pode ser lido é reportado sem interromper os demais.
+func (cfg config) newRecordReader() (models.RecordReader, error) {
+	format := cfg.inputFormat()
+	switch format {
+	case formatCSV, formatJSONL, "ndjson", formatJSON, formatXLSX:
+	default:
+		return nil, fmt.Errorf("formato de entrada desconhecido: %s", format)
+	}
+
+	if format == formatXLSX {
+		// XLSX já é um zip; é lido diretamente, sem passar por input.Entries
+		opts, err := cfg.readerOptions()
+		if err != nil {
+			return nil, err
+		}
+		return xlsxreader.NewReaderWithOptions(cfg.csvFile, xlsxreader.Options{
+			Sheet:       cfg.sheet,
+			DateLayouts: opts.DateLayouts,
+		}), nil
+	}
+
+	entries, err := input.Entries(cfg.csvFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var sources []input.Source
+	for _, entry := range entries {
+		entryFormat := format
+		if entry.Archive != "" && !cfg.explicitFormat() {
+			if entryFormat = archiveFormatFromName(entry.Name); entryFormat == "" {
+				continue
+			}
+		}
+
+		reader, err := cfg.entryReader(entry, entryFormat)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, input.Source{Name: entry.Name, Reader: reader})
+	}
+
+	switch len(sources) {
+	case 0:
+		return nil, fmt.Errorf("nenhum arquivo de dados encontrado em %s", cfg.csvFile)
+	case 1:
+		return sources[0].Reader, nil
+	default:
+		return input.NewMultiReader(sources...), nil
+	}
+}
+
+// entryReader cria o leitor de um arquivo de dados no formato informado
+func (cfg config) entryReader(entry input.Entry, format string) (models.RecordReader, error) {
+	switch format {
+	case formatCSV:
+		opts, err := cfg.readerOptions()
+		if err != nil {
+			return nil, err
+		}
+		return csvreader.NewReaderFromSource(entry.Name, entry.Open, opts), nil
+	case formatJSONL, "ndjson":
+		return jsonreader.NewReaderFromSource(entry.Name, entry.Open, jsonreader.FormatLines), nil
+	case formatJSON:
+		// Um arquivo .json pode conter tanto um array quanto JSON Lines
+		return jsonreader.NewReaderFromSource(entry.Name, entry.Open, jsonreader.FormatAuto), nil
+	case formatXLSX:
+		return nil, fmt.Errorf("%s: planilhas XLSX dentro de um zip não são suportadas", entry.Name)
+	default:
+		return nil, fmt.Errorf("formato de entrada desconhecido: %s", format)
+	}
+}
@@ -1,14 +1,20 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"log"
+	"log/slog"
+	"net"
 	"os"
 	"runtime"
 	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/seu-usuario/worker-pool-csv-processor/internal/csvreader"
 	"github.com/seu-usuario/worker-pool-csv-processor/internal/database"
@@ -17,78 +23,178 @@ import (
 	"github.com/seu-usuario/worker-pool-csv-processor/internal/workerpool"
 )
 
+// config agrupa as opções de execução do processador
+type config struct {
+	csvFile         string
+	dbPath          string
+	workers         int
+	autoscale       bool
+	minWorkers      int
+	maxWorkers      int
+	queueSize       int
+	taskTimeout     time.Duration
+	shutdownTimeout time.Duration
+	logLevel        string
+	logJSON         bool
+	metricsAddr     string
+	delimiter       string
+	charset         string
+	decimalComma    bool
+	dateLayouts     string
+	format          string
+	sheet           string
+	rulesFile       string
+	emailChecks     bool
+	emailMX         bool
+	rejectTypos     bool
+}
+
 func main() {
+	// Subcomando de manutenção do cadastro de departamentos
+	if len(os.Args) > 1 && os.Args[1] == "departments" {
+		if err := runDepartments(os.Args[2:], os.Stdout); err != nil {
+			log.Fatalf("❌ %v", err)
+		}
+		return
+	}
+
 	// Parse de flags de linha de comando
-	var (
-		csvFile   = flag.String("csv", "data/employees.csv", "Caminho do arquivo CSV")
-		dbPath    = flag.String("db", "employees.db", "Caminho do banco de dados SQLite")
-		workers   = flag.Int("workers", runtime.NumCPU()*2, "Número de workers")
-		queueSize = flag.Int("queue", 100, "Tamanho da fila de tarefas")
-		showStats = flag.Bool("stats", false, "Mostra estatísticas do banco e sai")
-	)
+	var cfg config
+	flag.StringVar(&cfg.csvFile, "csv", "data/employees.csv", "Caminho do arquivo de entrada (CSV, JSON Lines, JSON ou XLSX)")
+	flag.StringVar(&cfg.format, "format", formatAuto, "Formato da entrada: auto (pela extensão), csv, jsonl, json ou xlsx")
+	flag.StringVar(&cfg.sheet, "sheet", "", "Planilha a ler em arquivos XLSX (vazio = primeira)")
+	flag.StringVar(&cfg.dbPath, "db", "employees.db", "Caminho do banco de dados SQLite")
+	flag.IntVar(&cfg.workers, "workers", runtime.NumCPU()*2, "Número de workers")
+	flag.BoolVar(&cfg.autoscale, "autoscale", false, "Ajusta o número de workers conforme a fila e a latência")
+	flag.IntVar(&cfg.minWorkers, "min-workers", 1, "Número mínimo de workers com -autoscale")
+	flag.IntVar(&cfg.maxWorkers, "max-workers", runtime.NumCPU()*4, "Número máximo de workers com -autoscale")
+	flag.IntVar(&cfg.queueSize, "queue", 100, "Tamanho da fila de tarefas")
+	flag.DurationVar(&cfg.taskTimeout, "task-timeout", 30*time.Second, "Tempo máximo de processamento de cada registro (0 = sem limite)")
+	flag.DurationVar(&cfg.shutdownTimeout, "shutdown-timeout", 5*time.Minute, "Tempo máximo para concluir as tarefas na fila ao encerrar")
+	flag.StringVar(&cfg.logLevel, "log-level", "warn", "Nível de log do worker pool: debug, info, warn, error ou off")
+	flag.BoolVar(&cfg.logJSON, "log-json", false, "Emite os logs do worker pool em JSON")
+	flag.StringVar(&cfg.metricsAddr, "metrics-addr", "", "Endereço do endpoint /metrics no formato Prometheus, ex.: :9090 (vazio = desativado)")
+	flag.StringVar(&cfg.delimiter, "delimiter", "", "Separador de campos do CSV (vazio = detecta pelo cabeçalho)")
+	flag.StringVar(&cfg.charset, "charset", "utf-8", "Codificação do CSV: utf-8, iso-8859-1 ou windows-1252")
+	flag.BoolVar(&cfg.decimalComma, "decimal-comma", false, "Números com vírgula decimal (1.234,56)")
+	flag.StringVar(&cfg.dateLayouts, "date-layouts", "2006-01-02", "Formatos de data aceitos, separados por vírgula, no padrão Go (ex.: 02/01/2006)")
+	flag.StringVar(&cfg.rulesFile, "rules", "", "Arquivo JSON com as regras de validação (vazio = regras padrão)")
+	flag.BoolVar(&cfg.emailChecks, "email-checks", false, "Validação estendida de email: normaliza domínios IDN, rejeita emails temporários e avisa de erros de digitação de provedores comuns")
+	flag.BoolVar(&cfg.emailMX, "email-mx", false, "Confere no DNS se o domínio de cada email recebe mensagens (implica -email-checks)")
+	flag.BoolVar(&cfg.rejectTypos, "email-reject-typos", false, "Rejeita emails com provável erro de digitação no domínio, em vez de apenas avisar (implica -email-checks)")
+	showStats := flag.Bool("stats", false, "Mostra estatísticas do banco e sai")
 	flag.Parse()
 
 	// Se apenas quer ver stats
 	if *showStats {
-		showDatabaseStats(*dbPath)
+		showDatabaseStats(cfg.dbPath)
 		return
 	}
 
-	// Valida arquivo CSV
-	if _, err := os.Stat(*csvFile); os.IsNotExist(err) {
-		log.Fatalf("❌ Arquivo CSV não encontrado: %s", *csvFile)
+	// Valida arquivo de entrada
+	if _, err := os.Stat(cfg.csvFile); os.IsNotExist(err) {
+		log.Fatalf("❌ Arquivo de entrada não encontrado: %s", cfg.csvFile)
 	}
 
 	fmt.Println("🚀 Worker Pool CSV Processor")
 	fmt.Println("============================")
-	fmt.Printf("📄 Arquivo CSV: %s\n", *csvFile)
-	fmt.Printf("💾 Banco de dados: %s\n", *dbPath)
-	fmt.Printf("👷 Workers: %d\n", *workers)
-	fmt.Printf("📋 Tamanho da fila: %d\n\n", *queueSize)
+	fmt.Printf("📄 Arquivo de entrada: %s (%s)\n", cfg.csvFile, cfg.inputFormat())
+	fmt.Printf("💾 Banco de dados: %s\n", cfg.dbPath)
+	fmt.Printf("👷 Workers: %d\n", cfg.workers)
+	if cfg.autoscale {
+		fmt.Printf("📐 Autoscaling: %d a %d workers\n", cfg.minWorkers, cfg.maxWorkers)
+	}
+	fmt.Printf("📋 Tamanho da fila: %d\n\n", cfg.queueSize)
 
 	// Inicia processamento
-	processCSV(*csvFile, *dbPath, *workers, *queueSize)
+	processCSV(cfg)
 }
 
-func processCSV(csvFile, dbPath string, workerCount, queueSize int) {
+// departmentCacheTTL é por quanto tempo a validação reaproveita uma consulta
+// ao cadastro de departamentos
+const departmentCacheTTL = time.Minute
+
+// newValidator cria o validador com as regras de -rules (ou as regras padrão),
+// conferindo os departamentos em departments e, com -email-checks,
+// -email-mx ou -email-reject-typos, os emails
+func (cfg config) newValidator(departments validator.DepartmentLookup) (*validator.Validator, error) {
+	opts := validator.Options{Departments: departments}
+	if cfg.rulesFile != "" {
+		rules, err := validator.LoadRules(cfg.rulesFile)
+		if err != nil {
+			return nil, err
+		}
+		opts.Rules = rules
+	}
+	if cfg.emailChecks || cfg.emailMX || cfg.rejectTypos {
+		opts.Email = &validator.EmailOptions{RejectTypos: cfg.rejectTypos}
+		if cfg.emailMX {
+			opts.Email.Resolver = net.DefaultResolver
+		}
+	}
+	return validator.NewValidatorWithOptions(opts)
+}
+
+func processCSV(cfg config) {
 	startTime := time.Now()
 
 	// 1. Abre conexão com banco de dados
-	db, err := database.NewDB(dbPath)
+	db, err := database.NewDB(cfg.dbPath)
 	if err != nil {
 		log.Fatalf("❌ Erro ao conectar ao banco de dados: %v", err)
 	}
 	defer db.Close()
 
-	// 2. Lê arquivo CSV
-	fmt.Println("📖 Lendo arquivo CSV...")
-	csvReader := csvreader.NewReader(csvFile)
-	records, parseErrors, err := csvReader.ReadAll()
+	// 2. Abre o arquivo de entrada, que é lido um registro por vez
+	fmt.Println("📖 Lendo arquivo de entrada em streaming...")
+	reader, err := cfg.newRecordReader()
 	if err != nil {
-		log.Fatalf("❌ Erro ao ler CSV: %v", err)
+		log.Fatalf("❌ %v", err)
 	}
-
-	fmt.Printf("✅ %d registros lidos do CSV\n", len(records))
-	if len(parseErrors) > 0 {
-		fmt.Printf("⚠️  %d erros ao parsear linhas:\n", len(parseErrors))
-		for _, e := range parseErrors[:min(5, len(parseErrors))] {
-			fmt.Printf("   - %v\n", e)
-		}
-		if len(parseErrors) > 5 {
-			fmt.Printf("   ... e mais %d erros\n", len(parseErrors)-5)
-		}
+	stream, err := reader.Open()
+	if err != nil {
+		log.Fatalf("❌ Erro ao ler arquivo de entrada: %v", err)
 	}
+	defer stream.Close()
 	fmt.Println()
 
 	// 3. Cria validador
-	validator := validator.NewValidator()
+	validator, err := cfg.newValidator(validator.NewDepartmentCache(db, departmentCacheTTL))
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
 
 	// 4. Cria Worker Pool
-	fmt.Printf("🏭 Criando Worker Pool com %d workers...\n", workerCount)
-	pool := workerpool.NewWorkerPool(workerCount, queueSize)
+	fmt.Printf("🏭 Criando Worker Pool com %d workers...\n", cfg.workers)
+	logger, err := newLogger(cfg.logLevel, cfg.logJSON)
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	pool := workerpool.NewPoolWithOptions[*models.Record, models.ProcessingResult](workerpool.Options{
+		Workers:   cfg.workers,
+		QueueSize: cfg.queueSize,
+		Logger:    logger,
+	})
 	fmt.Printf("🚀 Iniciando workers...\n\n")
 	pool.Start()
-	defer pool.Stop()
+	defer func() {
+		pool.Stop()
+		// Handlers abandonados por timeout ainda usam o banco, fechado logo depois
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.shutdownTimeout)
+		defer cancel()
+		if err := pool.Wait(ctx); err != nil {
+			fmt.Println("⏱️  Tempo de encerramento esgotado: handlers ainda em execução")
+		}
+	}()
+
+	if cfg.autoscale {
+		scaler := workerpool.NewAutoscaler(pool, workerpool.AutoscalerConfig{
+			MinWorkers: cfg.minWorkers,
+			MaxWorkers: cfg.maxWorkers,
+		})
+		scaler.Start()
+		defer scaler.Stop()
+	}
 
 	// Aguarda um momento para workers iniciarem
 	time.Sleep(100 * time.Millisecond)
@@ -97,131 +203,225 @@ func processCSV(csvFile, dbPath string, workerCount, queueSize int) {
 	var wg sync.WaitGroup
 	var mu sync.Mutex
 	var (
-		processedCount int
-		successCount   int
-		failedCount    int
-		results        []models.ProcessingResult
+		readCount       int
+		processedCount  int
+		successCount    int
+		failedCount     int
+		parseErrorCount int
+		skippedSources  int
+		warningCount    int
+		// Apenas os primeiros erros são guardados, para manter a memória constante
+		parseErrors []error
+		failures    []models.ProcessingResult
+		warnings    []*models.ValidationError
+		// Erros de leitura e de validação por campo e regra
+		fieldErrors = fieldErrorCounts{}
 	)
 
-	// Canal para coletar resultados
-	resultsChan := make(chan models.ProcessingResult, len(records))
-
-	// Submete tarefas ao pool
-	fmt.Printf("📤 Submetendo %d tarefas ao Worker Pool...\n\n", len(records))
-	for i, record := range records {
-		recordCopy := record // Importante: cópia para closure
-
-		task := workerpool.Task{
-			ID:      i + 1,
-			Payload: recordCopy,
-			Handler: func(payload interface{}) (interface{}, error) {
-				rec := payload.(*models.Record)
-
-				// Valida registro
-				if err := validator.Validate(rec); err != nil {
-					return models.ProcessingResult{
-						RowNumber: rec.RowNumber,
-						Record:    rec,
-						Success:   false,
-						Error:     err,
-					}, nil
-				}
-
-				// Insere no banco de dados
-				if err := db.InsertRecord(rec); err != nil {
-					return models.ProcessingResult{
-						RowNumber: rec.RowNumber,
-						Record:    rec,
-						Success:   false,
-						Error:     err,
-					}, nil
+	if cfg.metricsAddr != "" {
+		server, err := startMetricsServer(cfg.metricsAddr, metricsSource{
+			pool: pool,
+			rows: func() rowCounts {
+				mu.Lock()
+				defer mu.Unlock()
+				return rowCounts{
+					read:      readCount,
+					processed: processedCount,
+					success:   successCount,
+					failed:    failedCount,
 				}
-
-				return models.ProcessingResult{
-					RowNumber: rec.RowNumber,
-					Record:    rec,
-					Success:   true,
-				}, nil
 			},
-			Result: make(chan workerpool.Result, 1),
-			Error:  make(chan error, 1),
-		}
-
-		if err := pool.Submit(task); err != nil {
-			fmt.Printf("  ❌ Erro ao submeter tarefa %d: %v\n", i+1, err)
-			continue
+		})
+		if err != nil {
+			log.Fatalf("❌ %v", err)
 		}
-
-		// Coleta resultado
-		wg.Add(1)
-		go func(t workerpool.Task) {
-			defer wg.Done()
-			select {
-			case result := <-t.Result:
-				if pr, ok := result.Output.(models.ProcessingResult); ok {
-					pr.Duration = result.Duration
-					resultsChan <- pr
-				}
-			case err := <-t.Error:
-				fmt.Printf("  ❌ Erro ao processar tarefa %d: %v\n", t.ID, err)
-			case <-time.After(30 * time.Second):
-				fmt.Printf("⏱️  Timeout processando tarefa %d\n", t.ID)
-			}
-		}(task)
+		defer stopMetricsServer(server)
+		fmt.Printf("📡 Métricas em http://%s/metrics\n\n", cfg.metricsAddr)
 	}
 
-	// Aguarda todos os resultados
-	go func() {
-		wg.Wait()
-		close(resultsChan)
-	}()
-
-	// Processa resultados
-	fmt.Println("\n⏳ Aguardando processamento...")
-	fmt.Println()
-	progressTicker := time.NewTicker(500 * time.Millisecond)
-	defer progressTicker.Stop()
+	// Canal para coletar resultados
+	resultsChan := make(chan models.ProcessingResult, cfg.queueSize)
 
 	done := make(chan bool)
 	go func() {
 		for result := range resultsChan {
 			mu.Lock()
 			processedCount++
+			warningCount += len(result.Warnings)
+			for _, warning := range result.Warnings {
+				if len(warnings) < 5 {
+					warnings = append(warnings, warning)
+				}
+			}
 			if result.Success {
 				successCount++
 			} else {
 				failedCount++
+				fieldErrors.add(result.Error)
+				if len(failures) < 5 {
+					failures = append(failures, result)
+				}
 			}
-			results = append(results, result)
 			mu.Unlock()
 		}
-		done <- true
+		// Fechar o canal libera tanto o progresso quanto a espera final
+		close(done)
 	}()
 
 	// Mostra progresso periódico
+	progressTicker := time.NewTicker(500 * time.Millisecond)
+	defer progressTicker.Stop()
+
 	go func() {
 		for {
 			select {
 			case <-progressTicker.C:
 				mu.Lock()
+				currentRead := readCount
 				currentProcessed := processedCount
 				currentSuccess := successCount
 				currentFailed := failedCount
 				mu.Unlock()
 
-				if currentProcessed < len(records) {
-					fmt.Printf("  📊 Progresso: %d/%d processados (✓ %d, ✗ %d)\n",
-						currentProcessed, len(records), currentSuccess, currentFailed)
-				}
+				fmt.Printf("  📊 Progresso: %d/%d processados (✓ %d, ✗ %d) | fila: %d\n",
+					currentProcessed, currentRead, currentSuccess, currentFailed, pool.QueueDepth())
 			case <-done:
 				return
 			}
 		}
 	}()
 
+	// Inserções que falham com o banco ocupado são repetidas com backoff
+	retryPolicy := workerpool.DefaultRetryPolicy()
+	retryPolicy.Retryable = database.IsTransient
+
+	// Submete tarefas ao pool à medida que as linhas são lidas
+	fmt.Printf("📤 Submetendo tarefas ao Worker Pool...\n\n")
+	var readErr error
+	for taskID := 1; ; {
+		record, err := stream.Next()
+		if err == io.EOF {
+			break
+		}
+		if models.IsValidationError(err) {
+			mu.Lock()
+			parseErrorCount++
+			fieldErrors.add(err)
+			if len(parseErrors) < 5 {
+				parseErrors = append(parseErrors, err)
+			}
+			mu.Unlock()
+			continue
+		}
+		if models.IsSourceError(err) {
+			// Um arquivo do zip que não pôde ser lido não impede os demais
+			fmt.Printf("  ⚠️  %v\n", err)
+			mu.Lock()
+			skippedSources++
+			mu.Unlock()
+			continue
+		}
+		if err != nil {
+			// Falha de leitura: as tarefas já submetidas ainda são concluídas
+			readErr = err
+			break
+		}
+
+		mu.Lock()
+		readCount++
+		mu.Unlock()
+
+		task := workerpool.TypedTask[*models.Record, models.ProcessingResult]{
+			ID:      taskID,
+			Payload: record,
+			ContextHandler: func(ctx context.Context, rec *models.Record) (models.ProcessingResult, error) {
+				return processRecord(ctx, validator, db, rec)
+			},
+			Timeout: cfg.taskTimeout,
+			Retry:   retryPolicy,
+			Result:  make(chan workerpool.TypedResult[*models.Record, models.ProcessingResult], 1),
+			Error:   make(chan error, 1),
+		}
+		taskID++
+
+		// SubmitWait aplica backpressure: a leitura do arquivo só avança quando há espaço na fila
+		if err := pool.SubmitWait(context.Background(), task); err != nil {
+			fmt.Printf("  ❌ Erro ao submeter tarefa %d: %v\n", task.ID, err)
+			continue
+		}
+
+		// Coleta resultado
+		wg.Add(1)
+		go func(t workerpool.TypedTask[*models.Record, models.ProcessingResult]) {
+			defer wg.Done()
+			select {
+			case result := <-t.Result:
+				pr := result.Output
+				pr.Duration = result.Duration
+				resultsChan <- pr
+			case err := <-t.Error:
+				if errors.Is(err, workerpool.ErrTaskTimeout) {
+					fmt.Printf("⏱️  Timeout processando tarefa %d\n", t.ID)
+				} else {
+					fmt.Printf("  ❌ Erro ao processar tarefa %d: %v\n", t.ID, err)
+				}
+				resultsChan <- models.ProcessingResult{
+					RowNumber: t.Payload.RowNumber,
+					Record:    t.Payload,
+					Success:   false,
+					Error:     err,
+				}
+			}
+		}(task)
+	}
+
+	// Encerra a entrada de tarefas e aguarda a fila esvaziar
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.shutdownTimeout)
+		defer cancel()
+
+		pending, err := pool.Shutdown(ctx)
+		if err != nil {
+			fmt.Printf("⏱️  Tempo de encerramento esgotado: %d tarefas não executadas\n", len(pending))
+		}
+		// Libera os coletores das tarefas que nunca chegaram a um worker
+		for _, t := range pending {
+			t.Error <- workerpool.ErrPoolStopped
+		}
+	}()
+
+	// Aguarda todos os resultados
+	go func() {
+		wg.Wait()
+		close(resultsChan)
+	}()
+
+	fmt.Println("\n⏳ Aguardando processamento...")
+	fmt.Println()
+
 	<-done
 	fmt.Println() // Nova linha após progresso
 
+	if readErr != nil {
+		fmt.Printf("❌ Leitura da entrada interrompida: %v\n", readErr)
+	}
+	fmt.Printf("✅ %d registros lidos da entrada\n", readCount)
+	if skippedSources > 0 {
+		fmt.Printf("⚠️  %d arquivos da entrada ignorados por erro de leitura\n", skippedSources)
+	}
+	if parseErrorCount > 0 {
+		fmt.Printf("⚠️  %d erros ao parsear linhas:\n", parseErrorCount)
+		for _, e := range parseErrors {
+			// Uma linha pode ter vários campos inválidos
+			for _, fieldErr := range models.ValidationErrorsOf(e) {
+				fmt.Printf("   - %v\n", fieldErr)
+			}
+		}
+		if parseErrorCount > len(parseErrors) {
+			fmt.Printf("   ... e mais %d erros\n", parseErrorCount-len(parseErrors))
+		}
+	}
+
 	// 6. Mostra estatísticas finais
 	totalDuration := time.Since(startTime)
 	poolMetrics := pool.GetMetrics()
@@ -237,23 +437,57 @@ func processCSV(csvFile, dbPath string, workerCount, queueSize int) {
 
 	fmt.Println("📈 MÉTRICAS DO WORKER POOL")
 	fmt.Println(strings.Repeat("-", 50))
+	fmt.Printf("Workers ao final: %d\n", len(poolMetrics.Workers))
 	fmt.Printf("Tarefas processadas: %d\n", poolMetrics.TasksProcessed)
 	fmt.Printf("Tarefas falharam: %d\n", poolMetrics.TasksFailed)
+	fmt.Printf("Tarefas com timeout: %d\n", poolMetrics.TasksTimedOut)
+	fmt.Printf("Tarefas com panic: %d\n", poolMetrics.TasksPanicked)
+	fmt.Printf("Tarefas com retry: %d (%d tentativas extras)\n", poolMetrics.TasksRetried, poolMetrics.TotalRetries)
 	fmt.Printf("Duração média: %v\n", poolMetrics.AverageDuration)
+	fmt.Printf("Latência p50/p95/p99: %v / %v / %v\n",
+		poolMetrics.Latency.P50, poolMetrics.Latency.P95, poolMetrics.Latency.P99)
+	fmt.Printf("Espera na fila p50/p95/p99: %v / %v / %v\n",
+		poolMetrics.QueueWait.P50, poolMetrics.QueueWait.P95, poolMetrics.QueueWait.P99)
+	for _, w := range poolMetrics.Workers {
+		fmt.Printf("  Worker %d: %d tarefas, ocupado %v (%.0f%%)\n",
+			w.ID, w.TasksProcessed, w.BusyTime.Round(time.Millisecond), w.Utilization*100)
+	}
 
 	// 7. Mostra alguns erros (se houver)
 	if failedCount > 0 {
 		fmt.Println("\n⚠️  PRIMEIROS ERROS ENCONTRADOS:")
 		fmt.Println(strings.Repeat("-", 50))
-		errorCount := 0
-		for _, result := range results {
-			if !result.Success && errorCount < 5 {
+		for _, result := range failures {
+			fieldErrs := models.ValidationErrorsOf(result.Error)
+			if len(fieldErrs) == 0 {
 				fmt.Printf("Linha %d: %v\n", result.RowNumber, result.Error)
-				errorCount++
+				continue
+			}
+			for _, fieldErr := range fieldErrs {
+				fmt.Printf("%v\n", fieldErr)
 			}
 		}
-		if failedCount > 5 {
-			fmt.Printf("... e mais %d erros\n", failedCount-5)
+		if failedCount > len(failures) {
+			fmt.Printf("... e mais %d erros\n", failedCount-len(failures))
+		}
+	}
+
+	if warningCount > 0 {
+		fmt.Println("\n💡 AVISOS (não impedem a importação):")
+		fmt.Println(strings.Repeat("-", 50))
+		for _, warning := range warnings {
+			fmt.Printf("%v\n", warning)
+		}
+		if warningCount > len(warnings) {
+			fmt.Printf("... e mais %d avisos\n", warningCount-len(warnings))
+		}
+	}
+
+	if len(fieldErrors) > 0 {
+		fmt.Println("\n📋 ERROS POR CAMPO")
+		fmt.Println(strings.Repeat("-", 50))
+		for _, c := range fieldErrors.sorted() {
+			fmt.Printf("%-15s %-10s %d\n", c.field, c.code, c.count)
 		}
 	}
 
@@ -278,6 +512,101 @@ func processCSV(csvFile, dbPath string, workerCount, queueSize int) {
 	fmt.Println("\n✅ Processamento concluído!")
 }
 
+// processRecord valida e insere um registro. Um registro inválido ou recusado
+// pelo banco gera um resultado sem sucesso; falhas ao consultar o banco, na
+// validação ou na inserção, são devolvidas ao pool, que repete as transitórias.
+func processRecord(ctx context.Context, v *validator.Validator, db *database.DB, rec *models.Record) (models.ProcessingResult, error) {
+	// Valida registro
+	warnings, err := v.Check(ctx, rec)
+	if err != nil && !models.IsValidationError(err) {
+		return models.ProcessingResult{}, err
+	}
+	if err != nil {
+		return models.ProcessingResult{
+			RowNumber: rec.RowNumber,
+			Record:    rec,
+			Success:   false,
+			Error:     err,
+			Warnings:  warnings,
+		}, nil
+	}
+
+	// Insere no banco de dados
+	if err := db.InsertRecordContext(ctx, rec); err != nil {
+		// Erros transitórios são devolvidos ao pool para nova tentativa
+		if database.IsTransient(err) {
+			return models.ProcessingResult{}, err
+		}
+		return models.ProcessingResult{
+			RowNumber: rec.RowNumber,
+			Record:    rec,
+			Success:   false,
+			Error:     err,
+		}, nil
+	}
+
+	return models.ProcessingResult{
+		RowNumber: rec.RowNumber,
+		Record:    rec,
+		Success:   true,
+		Warnings:  warnings,
+	}, nil
+}
+
+// readerOptions converte as flags de formato do CSV em csvreader.Options
+func (cfg config) readerOptions() (csvreader.Options, error) {
+	charset, err := csvreader.ParseCharset(cfg.charset)
+	if err != nil {
+		return csvreader.Options{}, err
+	}
+
+	opts := csvreader.Options{
+		Charset:          charset,
+		DecimalSeparator: '.',
+	}
+	if cfg.decimalComma {
+		opts.DecimalSeparator = ','
+	}
+
+	if cfg.delimiter != "" {
+		// A tabulação pode ser informada como \t na linha de comando
+		if cfg.delimiter == `\t` {
+			cfg.delimiter = "\t"
+		}
+		comma, size := utf8.DecodeRuneInString(cfg.delimiter)
+		if size != len(cfg.delimiter) {
+			return csvreader.Options{}, fmt.Errorf("separador inválido: %q", cfg.delimiter)
+		}
+		opts.Comma = comma
+	}
+
+	for _, layout := range strings.Split(cfg.dateLayouts, ",") {
+		if layout = strings.TrimSpace(layout); layout != "" {
+			opts.DateLayouts = append(opts.DateLayouts, layout)
+		}
+	}
+	return opts, nil
+}
+
+// newLogger cria o logger estruturado do worker pool, escrevendo em stderr.
+// O nível "off" retorna nil, deixando o pool em modo silencioso.
+func newLogger(level string, asJSON bool) (*slog.Logger, error) {
+	if level == "off" {
+		return nil, nil
+	}
+
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("nível de log inválido: %s", level)
+	}
+
+	opts := &slog.HandlerOptions{Level: lvl}
+	if asJSON {
+		return slog.New(slog.NewJSONHandler(os.Stderr, opts)), nil
+	}
+	return slog.New(slog.NewTextHandler(os.Stderr, opts)), nil
+}
+
 func showDatabaseStats(dbPath string) {
 	db, err := database.NewDB(dbPath)
 	if err != nil {
@@ -303,10 +632,3 @@ func showDatabaseStats(dbPath string) {
 		}
 	}
 }
-
-func min(a, b int) int {
-	if a < b {
-		return a
-	}
-	return b
-}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+	"github.com/seu-usuario/worker-pool-csv-processor/internal/csvreader"
+	"github.com/seu-usuario/worker-pool-csv-processor/internal/database"
+	"github.com/seu-usuario/worker-pool-csv-processor/internal/models"
+	"github.com/seu-usuario/worker-pool-csv-processor/internal/validator"
+)
+
+func TestConfig_ReaderOptions(t *testing.T) {
+	cfg := config{
+		delimiter:    `\t`,
+		charset:      "latin1",
+		decimalComma: true,
+		dateLayouts:  "02/01/2006, 2006-01-02",
+	}
+
+	opts, err := cfg.readerOptions()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if opts.Comma != '\t' {
+		t.Errorf("Expected tab delimiter, got %q", opts.Comma)
+	}
+	if opts.Charset != csvreader.CharsetISO88591 {
+		t.Errorf("Expected ISO-8859-1, got %q", opts.Charset)
+	}
+	if opts.DecimalSeparator != ',' {
+		t.Errorf("Expected decimal comma, got %q", opts.DecimalSeparator)
+	}
+	if len(opts.DateLayouts) != 2 || opts.DateLayouts[0] != "02/01/2006" {
+		t.Errorf("Unexpected date layouts: %q", opts.DateLayouts)
+	}
+}
+
+func TestConfig_ReaderOptionsInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config
+	}{
+		{"Unknown charset", config{charset: "ebcdic"}},
+		{"Multi-character delimiter", config{delimiter: ";;"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.cfg.readerOptions(); err == nil {
+				t.Error("Expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestConfig_InputFormat(t *testing.T) {
+	tests := []struct {
+		file     string
+		format   string
+		expected string
+	}{
+		{"data/employees.csv", "auto", formatCSV},
+		{"export.JSONL", "auto", formatJSONL},
+		{"export.ndjson", "", formatJSONL},
+		{"export.json", "auto", formatJSON},
+		{"export.txt", "auto", formatCSV},
+		{"export.txt", "jsonl", formatJSONL},
+		{"rh/Funcionarios.XLSX", "auto", formatXLSX},
+		{"employees.csv.gz", "auto", formatCSV},
+		{"export.jsonl.zst", "auto", formatJSONL},
+		{"lote.zip", "auto", formatCSV},
+	}
+
+	for _, tt := range tests {
+		cfg := config{csvFile: tt.file, format: tt.format}
+		if got := cfg.inputFormat(); got != tt.expected {
+			t.Errorf("inputFormat(%q, %q) = %q, expected %q", tt.file, tt.format, got, tt.expected)
+		}
+	}
+}
+
+func TestConfig_NewRecordReader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "export.json")
+	if err := os.WriteFile(path, []byte("[]"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if _, err := (config{csvFile: path, format: "json"}).newRecordReader(); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if _, err := (config{csvFile: "export.xml", format: "xml"}).newRecordReader(); err == nil {
+		t.Error("Expected error for unknown format, got nil")
+	}
+}
+
+func TestConfig_NewRecordReaderZip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lote.zip")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Failed to create file: %v", err)
+	}
+	archive := zip.NewWriter(file)
+	// Os itens são lidos na ordem do zip; LEIAME e os metadados são ignorados,
+	// e um CSV sem as colunas obrigatórias não impede a leitura dos demais
+	for _, item := range []struct{ name, content string }{
+		{"rh.csv", "name,email,age,salary,department,is_active,created_at\nJoão,joao@empresa.com,28,5500,TI,true,2024-01-15\n"},
+		{"LEIAME.txt", "Arquivos exportados pelo RH"},
+		{"quebrado.csv", "nome;idade\nAna;30\n"},
+		{"ti.jsonl", `{"name":"Maria","email":"maria@empresa.com","age":32,"salary":6200,"department":"TI","is_active":true,"created_at":"2024-01-16"}` + "\n"},
+		{"LEIAME.md", "ignorado"},
+		{".DS_Store", "ignorado"},
+		{"dir/", ""},
+	} {
+		w, err := archive.Create(item.name)
+		if err != nil {
+			t.Fatalf("Failed to create zip entry: %v", err)
+		}
+		w.Write([]byte(item.content))
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatalf("Failed to close zip: %v", err)
+	}
+	file.Close()
+
+	reader, err := (config{csvFile: path, format: "auto"}).newRecordReader()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	records, parseErrors, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	var sourceErr *models.SourceError
+	if len(parseErrors) != 1 || !errors.As(parseErrors[0], &sourceErr) || sourceErr.Source != "quebrado.csv" {
+		t.Fatalf("Expected a source error for quebrado.csv, got %v", parseErrors)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(records))
+	}
+	if records[0].Source != "rh.csv" || records[1].Source != "ti.jsonl" {
+		t.Errorf("Expected sources rh.csv and ti.jsonl, got %q and %q", records[0].Source, records[1].Source)
+	}
+}
+
+func TestConfig_NewValidator(t *testing.T) {
+	if _, err := (config{}).newValidator(nil); err != nil {
+		t.Errorf("Expected default validator, got %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "regras.json")
+	if err := os.WriteFile(path, []byte(`{"fields": {"idade": {"min": 18}}}`), 0644); err != nil {
+		t.Fatalf("Failed to write rules: %v", err)
+	}
+	if _, err := (config{rulesFile: path}).newValidator(nil); !errors.Is(err, validator.ErrInvalidRules) {
+		t.Errorf("Expected ErrInvalidRules, got %v", err)
+	}
+
+	v, err := (config{emailChecks: true}).newValidator(nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	record := &models.Record{Name: "João Silva", Email: "joao@gmial.com", Age: 28, Salary: 5500, Department: "TI"}
+	warnings, err := v.Check(context.Background(), record)
+	if err != nil || !errors.Is(warnings.Err(), models.ErrTypo) {
+		t.Errorf("Expected ErrTypo warning with -email-checks, got %v / %v", err, warnings)
+	}
+}
+
+// busyDepartments simula o banco ocupado ao consultar o cadastro
+type busyDepartments struct{}
+
+func (busyDepartments) IsActiveDepartment(name string) (bool, error) {
+	return false, fmt.Errorf("erro ao consultar departamento: %w", sqlite3.Error{Code: sqlite3.ErrBusy})
+}
+
+func TestProcessRecord(t *testing.T) {
+	db, err := database.NewDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	newRecord := func() *models.Record {
+		return &models.Record{
+			Name: "João Silva", Email: "joao@empresa.com", Age: 28, Salary: 5500,
+			Department: "TI", IsActive: true, CreatedAt: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), RowNumber: 2,
+		}
+	}
+
+	// Banco ocupado na validação: o erro volta ao pool, que o repete
+	busy, err := validator.NewValidatorWithOptions(validator.Options{Departments: busyDepartments{}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := processRecord(context.Background(), busy, db, newRecord()); !database.IsTransient(err) {
+		t.Errorf("Expected transient error for the pool to retry, got %v", err)
+	}
+
+	v := validator.NewValidator()
+
+	// Registro inválido: resultado sem sucesso, sem erro para o pool
+	invalid := newRecord()
+	invalid.Age = 17
+	result, err := processRecord(context.Background(), v, db, invalid)
+	if err != nil || result.Success || !errors.Is(result.Error, models.ErrRange) {
+		t.Errorf("Expected failed result with range error, got %+v / %v", result, err)
+	}
+
+	result, err = processRecord(context.Background(), v, db, newRecord())
+	if err != nil || !result.Success {
+		t.Errorf("Expected success, got %+v / %v", result, err)
+	}
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/seu-usuario/worker-pool-csv-processor/internal/workerpool"
+)
+
+// poolStats é a parte do worker pool exposta em /metrics
+type poolStats interface {
+	GetMetrics() workerpool.Metrics
+	QueueDepthByPriority() map[workerpool.Priority]int
+	GetWorkerCount() int
+}
+
+// rowCounts são os contadores de linhas mantidos por processCSV
+type rowCounts struct {
+	read      int
+	processed int
+	success   int
+	failed    int
+}
+
+// metricsSource reúne as fontes do endpoint /metrics
+type metricsSource struct {
+	pool poolStats
+	rows func() rowCounts
+}
+
+// startMetricsServer serve /metrics em addr no formato de exposição do Prometheus.
+// O listener é aberto antes de retornar, para que um endereço inválido seja reportado de imediato.
+func startMetricsServer(addr string, src metricsSource) (*http.Server, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao abrir endpoint de métricas: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		writeMetrics(w, src)
+	})
+
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go server.Serve(listener)
+
+	return server, nil
+}
+
+// stopMetricsServer encerra o endpoint, aguardando as leituras em andamento
+func stopMetricsServer(server *http.Server) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	server.Shutdown(ctx)
+}
+
+// writeMetrics escreve as métricas do pool e do processamento no formato texto do Prometheus
+func writeMetrics(out io.Writer, src metricsSource) {
+	w := &promWriter{w: bufio.NewWriter(out)}
+	defer w.w.Flush()
+
+	m := src.pool.GetMetrics()
+
+	w.metric("workerpool_tasks_processed_total", "counter", "Tarefas concluídas pelo pool, com sucesso ou falha.", float64(m.TasksProcessed))
+	w.metric("workerpool_tasks_failed_total", "counter", "Tarefas que terminaram com erro.", float64(m.TasksFailed))
+	w.metric("workerpool_tasks_retried_total", "counter", "Tarefas que precisaram de mais de uma tentativa.", float64(m.TasksRetried))
+	w.metric("workerpool_retries_total", "counter", "Tentativas extras somadas de todas as tarefas.", float64(m.TotalRetries))
+	w.metric("workerpool_tasks_timed_out_total", "counter", "Tarefas interrompidas pelo prazo.", float64(m.TasksTimedOut))
+	w.metric("workerpool_tasks_panicked_total", "counter", "Tarefas cujo handler entrou em panic.", float64(m.TasksPanicked))
+	w.metric("workerpool_tasks_in_flight", "gauge", "Tarefas em execução.", float64(m.TasksInFlight))
+	w.metric("workerpool_workers", "gauge", "Número de workers em execução.", float64(src.pool.GetWorkerCount()))
+
+	w.header("workerpool_queue_depth", "gauge", "Tarefas aguardando um worker, por prioridade.")
+	depths := src.pool.QueueDepthByPriority()
+	for _, p := range []workerpool.Priority{workerpool.PriorityHigh, workerpool.PriorityNormal, workerpool.PriorityLow} {
+		w.sample("workerpool_queue_depth", `priority="`+p.String()+`"`, float64(depths[p]))
+	}
+
+	w.histogram("workerpool_task_duration_seconds", "Tempo de execução das tarefas, sem a espera na fila.", m.Latency)
+	w.histogram("workerpool_queue_wait_seconds", "Tempo entre o envio da tarefa e a entrega a um worker.", m.QueueWait)
+
+	w.header("workerpool_worker_tasks_total", "counter", "Tarefas concluídas por worker.")
+	for _, wm := range m.Workers {
+		w.sample("workerpool_worker_tasks_total", workerLabel(wm), float64(wm.TasksProcessed))
+	}
+	w.header("workerpool_worker_busy_seconds_total", "counter", "Tempo gasto executando tarefas, por worker.")
+	for _, wm := range m.Workers {
+		w.sample("workerpool_worker_busy_seconds_total", workerLabel(wm), wm.BusyTime.Seconds())
+	}
+	w.header("workerpool_worker_utilization", "gauge", "Fração do tempo de vida em que o worker esteve ocupado.")
+	for _, wm := range m.Workers {
+		w.sample("workerpool_worker_utilization", workerLabel(wm), wm.Utilization)
+	}
+
+	rows := src.rows()
+	w.metric("processor_rows_read", "gauge", "Registros lidos do arquivo de entrada.", float64(rows.read))
+	w.metric("processor_rows_processed_total", "counter", "Registros com resultado final.", float64(rows.processed))
+	w.metric("processor_rows_success_total", "counter", "Registros validados e gravados no banco.", float64(rows.success))
+	w.metric("processor_rows_failed_total", "counter", "Registros rejeitados ou com erro.", float64(rows.failed))
+}
+
+// workerLabel retorna o label que identifica o worker
+func workerLabel(wm workerpool.WorkerMetrics) string {
+	return `worker="` + strconv.Itoa(wm.ID) + `"`
+}
+
+// promWriter escreve amostras no formato texto do Prometheus
+type promWriter struct {
+	w *bufio.Writer
+}
+
+// header escreve as linhas HELP e TYPE de uma métrica
+func (p *promWriter) header(name, kind, help string) {
+	fmt.Fprintf(p.w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+// sample escreve uma amostra, com labels opcionais
+func (p *promWriter) sample(name, labels string, value float64) {
+	if labels != "" {
+		name += "{" + labels + "}"
+	}
+	fmt.Fprintf(p.w, "%s %s\n", name, formatFloat(value))
+}
+
+// metric escreve uma métrica sem labels
+func (p *promWriter) metric(name, kind, help string, value float64) {
+	p.header(name, kind, help)
+	p.sample(name, "", value)
+}
+
+// histogram escreve um histograma com buckets acumulados em segundos
+func (p *promWriter) histogram(name, help string, s workerpool.LatencySummary) {
+	p.header(name, "histogram", help)
+	for _, bucket := range s.Buckets {
+		le := "+Inf"
+		if bucket.UpperBound != math.MaxInt64 {
+			le = formatFloat(bucket.UpperBound.Seconds())
+		}
+		p.sample(name+"_bucket", `le="`+le+`"`, float64(bucket.Count))
+	}
+	p.sample(name+"_sum", "", s.Sum.Seconds())
+	p.sample(name+"_count", "", float64(s.Count))
+}
+
+// formatFloat formata um valor como o Prometheus espera
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
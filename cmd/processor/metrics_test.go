@@ -0,0 +1,77 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/seu-usuario/worker-pool-csv-processor/internal/workerpool"
+)
+
+// fakePool é um poolStats com valores fixos
+type fakePool struct {
+	metrics workerpool.Metrics
+}
+
+func (f fakePool) GetMetrics() workerpool.Metrics { return f.metrics }
+func (f fakePool) GetWorkerCount() int            { return len(f.metrics.Workers) }
+func (f fakePool) QueueDepthByPriority() map[workerpool.Priority]int {
+	return map[workerpool.Priority]int{workerpool.PriorityNormal: 7}
+}
+
+func TestWriteMetrics(t *testing.T) {
+	pool := fakePool{metrics: workerpool.Metrics{
+		TasksProcessed: 12,
+		TasksFailed:    2,
+		TasksInFlight:  1,
+		Latency: workerpool.LatencySummary{
+			Count: 3,
+			Sum:   1500 * time.Millisecond,
+			Buckets: []workerpool.HistogramBucket{
+				{UpperBound: 100 * time.Millisecond, Count: 1},
+				{UpperBound: math.MaxInt64, Count: 3},
+			},
+		},
+		Workers: []workerpool.WorkerMetrics{
+			{ID: 0, TasksProcessed: 12, BusyTime: 2 * time.Second, Utilization: 0.5},
+		},
+	}}
+
+	var out strings.Builder
+	writeMetrics(&out, metricsSource{
+		pool: pool,
+		rows: func() rowCounts { return rowCounts{read: 20, processed: 12, success: 10, failed: 2} },
+	})
+	text := out.String()
+
+	expected := []string{
+		"# TYPE workerpool_tasks_processed_total counter\nworkerpool_tasks_processed_total 12\n",
+		"workerpool_tasks_failed_total 2\n",
+		"workerpool_tasks_in_flight 1\n",
+		"workerpool_workers 1\n",
+		`workerpool_queue_depth{priority="normal"} 7` + "\n",
+		`workerpool_queue_depth{priority="high"} 0` + "\n",
+		"# TYPE workerpool_task_duration_seconds histogram\n",
+		`workerpool_task_duration_seconds_bucket{le="0.1"} 1` + "\n",
+		`workerpool_task_duration_seconds_bucket{le="+Inf"} 3` + "\n",
+		"workerpool_task_duration_seconds_sum 1.5\n",
+		"workerpool_task_duration_seconds_count 3\n",
+		`workerpool_worker_busy_seconds_total{worker="0"} 2` + "\n",
+		`workerpool_worker_utilization{worker="0"} 0.5` + "\n",
+		"processor_rows_read 20\n",
+		"processor_rows_success_total 10\n",
+		"processor_rows_failed_total 2\n",
+	}
+	for _, line := range expected {
+		if !strings.Contains(text, line) {
+			t.Errorf("Expected output to contain %q, got:\n%s", line, text)
+		}
+	}
+}
+
+func TestStartMetricsServer_InvalidAddr(t *testing.T) {
+	if _, err := startMetricsServer("endereço-inválido", metricsSource{}); err == nil {
+		t.Error("Expected error for invalid address")
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"sort"
+
+	"github.com/seu-usuario/worker-pool-csv-processor/internal/models"
+)
+
+// fieldRule identifica um campo e a regra violada nele
+type fieldRule struct {
+	field string
+	code  string
+}
+
+// fieldErrorCount é o total de erros de um campo e regra
+type fieldErrorCount struct {
+	fieldRule
+	count int
+}
+
+// fieldErrorCounts conta os erros de validação por campo e regra, para o resumo final
+type fieldErrorCounts map[fieldRule]int
+
+// add conta cada *models.ValidationError contido em err; outros erros são ignorados
+func (c fieldErrorCounts) add(err error) {
+	for _, validationErr := range models.ValidationErrorsOf(err) {
+		c[fieldRule{field: validationErr.Field, code: validationErr.Code}]++
+	}
+}
+
+// sorted retorna os totais do maior para o menor, com empates por campo e regra
+func (c fieldErrorCounts) sorted() []fieldErrorCount {
+	counts := make([]fieldErrorCount, 0, len(c))
+	for rule, count := range c {
+		counts = append(counts, fieldErrorCount{fieldRule: rule, count: count})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].count != counts[j].count {
+			return counts[i].count > counts[j].count
+		}
+		if counts[i].field != counts[j].field {
+			return counts[i].field < counts[j].field
+		}
+		return counts[i].code < counts[j].code
+	})
+	return counts
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/seu-usuario/worker-pool-csv-processor/internal/models"
+)
+
+func TestFieldErrorCounts(t *testing.T) {
+	counts := fieldErrorCounts{}
+	counts.add(models.ValidationErrors{
+		{Field: "email", Code: models.CodePattern},
+		{Field: "age", Code: models.CodeRange},
+	})
+	counts.add(&models.ValidationError{Field: "email", Code: models.CodePattern})
+	counts.add(models.ValidationErrors{{Field: "department", Code: models.CodeEnum}})
+	counts.add(errors.New("erro de banco")) // ignorado
+
+	sorted := counts.sorted()
+	expected := []fieldErrorCount{
+		{fieldRule{"email", models.CodePattern}, 2},
+		{fieldRule{"age", models.CodeRange}, 1},
+		{fieldRule{"department", models.CodeEnum}, 1},
+	}
+	if len(sorted) != len(expected) {
+		t.Fatalf("Expected %d counts, got %v", len(expected), sorted)
+	}
+	for i := range expected {
+		if sorted[i] != expected[i] {
+			t.Errorf("Expected %v at %d, got %v", expected[i], i, sorted[i])
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package csvreader
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Colunas reconhecidas pelo leitor
+const (
+	ColumnName       = "name"
+	ColumnEmail      = "email"
+	ColumnAge        = "age"
+	ColumnSalary     = "salary"
+	ColumnDepartment = "department"
+	ColumnIsActive   = "is_active"
+	ColumnCreatedAt  = "created_at"
+)
+
+// requiredColumns são as colunas que todo arquivo deve ter, em qualquer ordem
+var requiredColumns = []string{
+	ColumnName,
+	ColumnEmail,
+	ColumnAge,
+	ColumnSalary,
+	ColumnDepartment,
+	ColumnIsActive,
+	ColumnCreatedAt,
+}
+
+var (
+	ErrMissingColumns  = errors.New("colunas obrigatórias ausentes no cabeçalho")
+	ErrDuplicateColumn = errors.New("coluna repetida no cabeçalho")
+)
+
+// DefaultAliases são os nomes alternativos aceitos sem configuração,
+// cobrindo os cabeçalhos em português mais comuns
+var DefaultAliases = map[string]string{
+	"nome":         ColumnName,
+	"e-mail":       ColumnEmail,
+	"idade":        ColumnAge,
+	"salario":      ColumnSalary,
+	"salário":      ColumnSalary,
+	"departamento": ColumnDepartment,
+	"ativo":        ColumnIsActive,
+	"active":       ColumnIsActive,
+	"data_criacao": ColumnCreatedAt,
+	"data_criação": ColumnCreatedAt,
+}
+
+// mergeAliases combina DefaultAliases com os apelidos informados, já normalizados
+func mergeAliases(extra map[string]string) map[string]string {
+	aliases := make(map[string]string, len(DefaultAliases)+len(extra))
+	for alias, column := range DefaultAliases {
+		aliases[normalizeColumn(alias)] = column
+	}
+	for alias, column := range extra {
+		aliases[normalizeColumn(alias)] = normalizeColumn(column)
+	}
+	return aliases
+}
+
+// MapHeader localiza as colunas reconhecidas em um cabeçalho, aplicando
+// DefaultAliases e os apelidos informados. Retorna a posição de cada coluna,
+// com as mesmas regras de Open; é usado também por leitores de outros formatos.
+func MapHeader(header []string, aliases map[string]string) (map[string]int, error) {
+	return newColumnIndex(header, mergeAliases(aliases))
+}
+
+// normalizeColumn padroniza um nome de coluna para comparação
+func normalizeColumn(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// columnIndex mapeia cada coluna reconhecida para sua posição na linha
+type columnIndex map[string]int
+
+// newColumnIndex monta o índice a partir do cabeçalho. Colunas desconhecidas
+// são ignoradas; colunas obrigatórias ausentes são reportadas todas de uma vez.
+func newColumnIndex(header []string, aliases map[string]string) (columnIndex, error) {
+	index := make(columnIndex, len(requiredColumns))
+
+	for position, name := range header {
+		column := normalizeColumn(name)
+		if canonical, ok := aliases[column]; ok {
+			column = canonical
+		}
+		if _, seen := index[column]; seen {
+			// Colunas extras repetidas não atrapalham; só as reconhecidas são ambíguas
+			if isRequired(column) {
+				return nil, fmt.Errorf("%w: %s", ErrDuplicateColumn, column)
+			}
+			continue
+		}
+		index[column] = position
+	}
+
+	var missing []string
+	for _, column := range requiredColumns {
+		if _, ok := index[column]; !ok {
+			missing = append(missing, column)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrMissingColumns, strings.Join(missing, ", "))
+	}
+
+	return index, nil
+}
+
+// isRequired indica se a coluna é uma das reconhecidas pelo leitor
+func isRequired(column string) bool {
+	for _, required := range requiredColumns {
+		if column == required {
+			return true
+		}
+	}
+	return false
+}
+
+// get retorna o valor da coluna na linha
+func (c columnIndex) get(row []string, column string) string {
+	return row[c[column]]
+}
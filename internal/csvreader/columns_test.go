@@ -0,0 +1,96 @@
+package csvreader
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestNewColumnIndex(t *testing.T) {
+	header := []string{" Salary ", "extra", "NAME", "email", "age", "department", "is_active", "created_at"}
+
+	index, err := newColumnIndex(header, DefaultAliases)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if index[ColumnName] != 2 || index[ColumnSalary] != 0 {
+		t.Errorf("Unexpected column positions: %v", index)
+	}
+}
+
+func TestNewColumnIndex_MissingColumnsReportedTogether(t *testing.T) {
+	header := []string{"name", "age", "department", "is_active"}
+
+	_, err := newColumnIndex(header, DefaultAliases)
+	if !errors.Is(err, ErrMissingColumns) {
+		t.Fatalf("Expected ErrMissingColumns, got %v", err)
+	}
+	for _, column := range []string{ColumnEmail, ColumnSalary, ColumnCreatedAt} {
+		if !strings.Contains(err.Error(), column) {
+			t.Errorf("Expected error to mention %q, got %v", column, err)
+		}
+	}
+}
+
+func TestNewColumnIndex_DuplicateColumn(t *testing.T) {
+	header := []string{"name", "nome", "email", "age", "salary", "department", "is_active", "created_at"}
+
+	if _, err := newColumnIndex(header, DefaultAliases); !errors.Is(err, ErrDuplicateColumn) {
+		t.Errorf("Expected ErrDuplicateColumn, got %v", err)
+	}
+
+	// Colunas desconhecidas repetidas são apenas ignoradas
+	header = []string{"obs", "name", "email", "age", "salary", "department", "is_active", "created_at", "obs"}
+	if _, err := newColumnIndex(header, DefaultAliases); err != nil {
+		t.Errorf("Expected no error for repeated extra columns, got %v", err)
+	}
+}
+
+func TestOpen_ColumnsByHeaderWithAliases(t *testing.T) {
+	csvContent := `departamento,criado_em,e-mail,nome,observacao,salario,idade,ativo
+TI,2024-01-15,joao@empresa.com,João Silva,qualquer coisa,5500.00,28,true`
+
+	filePath, err := createTempCSV(csvContent)
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(filePath)
+
+	reader := NewReaderWithOptions(filePath, Options{
+		Aliases: map[string]string{"Criado_Em": ColumnCreatedAt},
+	})
+	records, parseErrors, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(parseErrors) != 0 {
+		t.Fatalf("Expected no parse errors, got %v", parseErrors)
+	}
+	if len(records) != 1 {
+		t.Fatalf("Expected 1 record, got %d", len(records))
+	}
+
+	rec := records[0]
+	if rec.Name != "João Silva" || rec.Email != "joao@empresa.com" || rec.Age != 28 ||
+		rec.Salary != 5500 || rec.Department != "TI" || !rec.IsActive {
+		t.Errorf("Unexpected record: %+v", rec)
+	}
+}
+
+func TestOpen_MissingColumns(t *testing.T) {
+	csvContent := `name,age,salary,department,is_active
+João Silva,28,5500.00,TI,true
+Maria Santos,32,6200.00,RH,true`
+
+	filePath, err := createTempCSV(csvContent)
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(filePath)
+
+	// O problema é reportado uma vez na abertura, e não uma vez por linha
+	if _, err := NewReader(filePath).Open(); !errors.Is(err, ErrMissingColumns) {
+		t.Errorf("Expected ErrMissingColumns, got %v", err)
+	}
+}
@@ -0,0 +1,192 @@
+package csvreader
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Charset identifica a codificação de caracteres do arquivo de entrada
+type Charset string
+
+const (
+	CharsetUTF8        Charset = "utf-8"
+	CharsetISO88591    Charset = "iso-8859-1"
+	CharsetWindows1252 Charset = "windows-1252"
+)
+
+// ParseCharset converte o nome de uma codificação, aceitando os apelidos mais comuns
+func ParseCharset(name string) (Charset, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "utf-8", "utf8":
+		return CharsetUTF8, nil
+	case "iso-8859-1", "iso8859-1", "latin1", "latin-1":
+		return CharsetISO88591, nil
+	case "windows-1252", "cp1252":
+		return CharsetWindows1252, nil
+	default:
+		return "", fmt.Errorf("codificação não suportada: %s", name)
+	}
+}
+
+// DefaultDateLayouts são os formatos de data aceitos quando nenhum é configurado
+var DefaultDateLayouts = []string{"2006-01-02"}
+
+// delimiterCandidates são os separadores considerados na detecção automática
+const delimiterCandidates = ",;\t|"
+
+// utf8BOM é a marca de ordem de bytes que alguns editores gravam no início do arquivo
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// openInput prepara o conteúdo do arquivo para o leitor CSV: remove o BOM,
+// converte a codificação para UTF-8 e detecta o separador, se necessário
+func (r *Reader) openInput(src io.Reader) (io.Reader, rune, error) {
+	input := bufio.NewReader(src)
+
+	if r.charset == CharsetUTF8 {
+		if prefix, _ := input.Peek(len(utf8BOM)); bytes.Equal(prefix, utf8BOM) {
+			input.Discard(len(utf8BOM))
+		}
+	} else {
+		input = bufio.NewReader(newDecoder(input, r.charset))
+	}
+
+	if r.comma != 0 {
+		return input, r.comma, nil
+	}
+
+	// O cabeçalho cabe no buffer do bufio.Reader; um erro aqui indica apenas
+	// que o arquivo é menor que o buffer
+	peeked, err := input.Peek(input.Size())
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, 0, fmt.Errorf("erro ao ler CSV: %w", err)
+	}
+	if newline := bytes.IndexByte(peeked, '\n'); newline >= 0 {
+		peeked = peeked[:newline]
+	}
+	return input, detectComma(peeked), nil
+}
+
+// detectComma escolhe o separador mais frequente no cabeçalho, ignorando
+// o conteúdo entre aspas. Sem nenhum candidato, usa vírgula.
+func detectComma(header []byte) rune {
+	counts := make(map[rune]int, len(delimiterCandidates))
+	quoted := false
+	for _, c := range string(header) {
+		switch {
+		case c == '"':
+			quoted = !quoted
+		case !quoted && strings.ContainsRune(delimiterCandidates, c):
+			counts[c]++
+		}
+	}
+
+	best := ','
+	for _, candidate := range delimiterCandidates {
+		if counts[candidate] > counts[best] {
+			best = candidate
+		}
+	}
+	return best
+}
+
+// windows1252 mapeia os bytes 0x80-0x9F, onde Windows-1252 difere de ISO-8859-1.
+// Os bytes não definidos mantêm o code point de mesmo valor.
+var windows1252 = [32]rune{
+	'€', 0x81, '‚', 'ƒ', '„', '…', '†', '‡', 'ˆ', '‰', 'Š', '‹', 'Œ', 0x8D, 'Ž', 0x8F,
+	0x90, '‘', '’', '“', '”', '•', '–', '—', '˜', '™', 'š', '›', 'œ', 0x9D, 'ž', 'Ÿ',
+}
+
+// decoder converte um fluxo em codificação de um byte por caractere para UTF-8
+type decoder struct {
+	src     io.Reader
+	charset Charset
+	in      [4096]byte
+	buf     []byte
+	out     []byte
+	err     error
+}
+
+// newDecoder cria um decoder para ISO-8859-1 ou Windows-1252
+func newDecoder(src io.Reader, charset Charset) *decoder {
+	return &decoder{src: src, charset: charset}
+}
+
+// decode converte um byte no caractere correspondente
+func (d *decoder) decode(b byte) rune {
+	if d.charset == CharsetWindows1252 && b >= 0x80 && b <= 0x9F {
+		return windows1252[b-0x80]
+	}
+	// Em ISO-8859-1 cada byte é o próprio code point
+	return rune(b)
+}
+
+func (d *decoder) Read(p []byte) (int, error) {
+	for len(d.out) == 0 {
+		if d.err != nil {
+			return 0, d.err
+		}
+
+		n, err := d.src.Read(d.in[:])
+		buf := d.buf[:0]
+		for _, b := range d.in[:n] {
+			buf = utf8.AppendRune(buf, d.decode(b))
+		}
+		d.buf, d.out, d.err = buf, buf, err
+	}
+
+	n := copy(p, d.out)
+	d.out = d.out[n:]
+	return n, nil
+}
+
+// parseDecimal converte um número conforme o separador decimal configurado,
+// aceitando separadores de milhar bem formados (ex.: 1.234,56 ou 1,234.56)
+func (r *Reader) parseDecimal(value string) (float64, error) {
+	decimal, thousands := ".", ","
+	if r.decimalSeparator == ',' {
+		decimal, thousands = ",", "."
+	}
+
+	value = strings.TrimSpace(value)
+	integer, fraction, hasFraction := strings.Cut(value, decimal)
+
+	if strings.Contains(integer, thousands) {
+		if !validGrouping(strings.TrimLeft(integer, "+-"), thousands) {
+			return 0, fmt.Errorf("separador de milhar inválido: %s", value)
+		}
+		integer = strings.ReplaceAll(integer, thousands, "")
+	}
+	if hasFraction {
+		integer += "." + fraction
+	}
+	return strconv.ParseFloat(integer, 64)
+}
+
+// validGrouping verifica se os dígitos estão agrupados de três em três
+func validGrouping(integer, separator string) bool {
+	groups := strings.Split(integer, separator)
+	for i, group := range groups {
+		if (i == 0 && (len(group) < 1 || len(group) > 3)) || (i > 0 && len(group) != 3) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseDate tenta cada formato de data configurado, na ordem
+func (r *Reader) parseDate(value string) (time.Time, error) {
+	var err error
+	for _, layout := range r.dateLayouts {
+		var date time.Time
+		if date, err = time.Parse(layout, strings.TrimSpace(value)); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, err
+}
@@ -0,0 +1,182 @@
+package csvreader
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseCharset(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected Charset
+	}{
+		{"", CharsetUTF8},
+		{"UTF8", CharsetUTF8},
+		{"latin1", CharsetISO88591},
+		{"ISO-8859-1", CharsetISO88591},
+		{"cp1252", CharsetWindows1252},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseCharset(tt.name)
+		if err != nil || got != tt.expected {
+			t.Errorf("ParseCharset(%q) = %q, %v; expected %q", tt.name, got, err, tt.expected)
+		}
+	}
+
+	if _, err := ParseCharset("ebcdic"); err == nil {
+		t.Error("Expected error for unsupported charset")
+	}
+}
+
+func TestDetectComma(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected rune
+	}{
+		{"name,email,age", ','},
+		{"nome;e-mail;idade", ';'},
+		{"name\temail\tage", '\t'},
+		{"name|email|age", '|'},
+		{`"nome, completo";email;idade`, ';'},
+		{"name", ','},
+	}
+
+	for _, tt := range tests {
+		if got := detectComma([]byte(tt.header)); got != tt.expected {
+			t.Errorf("detectComma(%q) = %q, expected %q", tt.header, got, tt.expected)
+		}
+	}
+}
+
+func TestDecoder(t *testing.T) {
+	tests := []struct {
+		name     string
+		charset  Charset
+		input    []byte
+		expected string
+	}{
+		{"Latin-1", CharsetISO88591, []byte{'J', 'o', 0xE3, 'o', ' ', 0xC7}, "João Ç"},
+		{"Windows-1252 euro and quotes", CharsetWindows1252, []byte{0x80, ' ', 0x93, 'a', 0x94}, "€ “a”"},
+		{"Latin-1 control range", CharsetISO88591, []byte{0x80}, "\u0080"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoded, err := io.ReadAll(newDecoder(strings.NewReader(string(tt.input)), tt.charset))
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if string(decoded) != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, decoded)
+			}
+		})
+	}
+}
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		separator rune
+		value     string
+		expected  float64
+		wantErr   bool
+	}{
+		{'.', "5500.00", 5500, false},
+		{'.', "1,234.56", 1234.56, false},
+		{'.', "5,5", 0, true},
+		{',', "1.234,56", 1234.56, false},
+		{',', "5500,00", 5500, false},
+		{',', "1.234.567", 1234567, false},
+		{',', "12.34,5", 0, true},
+		{',', "abc", 0, true},
+	}
+
+	for _, tt := range tests {
+		r := NewReaderWithOptions("", Options{DecimalSeparator: tt.separator})
+		got, err := r.parseDecimal(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseDecimal(%q, %q): unexpected error %v", tt.value, tt.separator, err)
+			continue
+		}
+		if !tt.wantErr && got != tt.expected {
+			t.Errorf("parseDecimal(%q, %q) = %v, expected %v", tt.value, tt.separator, got, tt.expected)
+		}
+	}
+}
+
+func TestParseDate_MultipleLayouts(t *testing.T) {
+	r := NewReaderWithOptions("", Options{DateLayouts: []string{"02/01/2006", "2006-01-02"}})
+	expected := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	for _, value := range []string{"15/01/2024", "2024-01-15"} {
+		got, err := r.parseDate(value)
+		if err != nil || !got.Equal(expected) {
+			t.Errorf("parseDate(%q) = %v, %v; expected %v", value, got, err, expected)
+		}
+	}
+	if _, err := r.parseDate("01-15-2024"); err == nil {
+		t.Error("Expected error for unknown layout")
+	}
+}
+
+func TestOpen_BrazilianLatin1File(t *testing.T) {
+	// "nome;e-mail;..." em ISO-8859-1, com separador ';', datas dd/mm/aaaa e decimais com vírgula
+	content := "nome;e-mail;idade;salario;departamento;ativo;data_criacao\n" +
+		"Jo\xe3o Silva;joao@empresa.com;28;\"1.234,56\";Opera\xe7\xf5es;true;15/01/2024\n"
+
+	filePath, err := createTempCSV(content)
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(filePath)
+
+	reader := NewReaderWithOptions(filePath, Options{
+		Charset:          CharsetISO88591,
+		DecimalSeparator: ',',
+		DateLayouts:      []string{"02/01/2006"},
+	})
+	records, parseErrors, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(parseErrors) != 0 {
+		t.Fatalf("Expected no parse errors, got %v", parseErrors)
+	}
+	if len(records) != 1 {
+		t.Fatalf("Expected 1 record, got %d", len(records))
+	}
+
+	rec := records[0]
+	if rec.Name != "João Silva" || rec.Department != "Operações" {
+		t.Errorf("Expected transcoded text, got name %q and department %q", rec.Name, rec.Department)
+	}
+	if rec.Salary != 1234.56 {
+		t.Errorf("Expected salary 1234.56, got %v", rec.Salary)
+	}
+	if expected := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC); !rec.CreatedAt.Equal(expected) {
+		t.Errorf("Expected date %v, got %v", expected, rec.CreatedAt)
+	}
+}
+
+func TestOpen_StripsUTF8BOM(t *testing.T) {
+	content := "\xEF\xBB\xBFname,email,age,salary,department,is_active,created_at\n" +
+		"João Silva,joao@empresa.com,28,5500.00,TI,true,2024-01-15\n"
+
+	filePath, err := createTempCSV(content)
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(filePath)
+
+	// Sem a remoção do BOM, a coluna "name" não seria encontrada
+	records, _, err := NewReader(filePath).ReadAll()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(records) != 1 {
+		t.Errorf("Expected 1 record, got %d", len(records))
+	}
+}
@@ -3,165 +3,236 @@ package csvreader
 import (
 	"encoding/csv"
 	"fmt"
-	"os"
+	"io"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/seu-usuario/worker-pool-csv-processor/internal/input"
 	"github.com/seu-usuario/worker-pool-csv-processor/internal/models"
 )
 
+// Reader implementa models.RecordReader
+var _ models.RecordReader = (*Reader)(nil)
+
 // Reader lê e processa arquivos CSV
 type Reader struct {
-	filePath string
+	filePath         string
+	open             func() (io.ReadCloser, error)
+	aliases          map[string]string
+	comma            rune
+	charset          Charset
+	decimalSeparator rune
+	dateLayouts      []string
+}
+
+// Options configura um Reader criado com NewReaderWithOptions
+type Options struct {
+	// Aliases mapeia nomes alternativos de coluna para os nomes reconhecidos
+	// (ex.: "e-mail" → "email"), complementando DefaultAliases
+	Aliases map[string]string
+	// Comma é o separador de campos (zero = detecta pelo cabeçalho entre ',', ';', tab e '|')
+	Comma rune
+	// Charset é a codificação do arquivo (vazio = CharsetUTF8). O BOM de UTF-8 é sempre removido.
+	Charset Charset
+	// DecimalSeparator é o separador decimal dos números: '.' (padrão, 1,234.56)
+	// ou ',' (formato brasileiro, 1.234,56)
+	DecimalSeparator rune
+	// DateLayouts são os formatos de data aceitos, no padrão do pacote time,
+	// tentados em ordem (vazio = DefaultDateLayouts)
+	DateLayouts []string
 }
 
 // NewReader cria uma nova instância do leitor CSV
 func NewReader(filePath string) *Reader {
+	return NewReaderWithOptions(filePath, Options{})
+}
+
+// NewReaderWithOptions cria uma nova instância do leitor CSV a partir de Options.
+// Arquivos compactados com gzip (ou zstd, veja input.ZstdDecoder) são descompactados durante a leitura.
+func NewReaderWithOptions(filePath string, opts Options) *Reader {
+	return NewReaderFromSource(filePath, func() (io.ReadCloser, error) {
+		return input.Open(filePath)
+	}, opts)
+}
+
+// NewReaderFromSource cria um leitor CSV para o conteúdo aberto por open, como
+// um item de um arquivo zip. source identifica a origem nos registros e erros.
+func NewReaderFromSource(source string, open func() (io.ReadCloser, error), opts Options) *Reader {
+	if opts.Charset == "" {
+		opts.Charset = CharsetUTF8
+	}
+	if opts.DecimalSeparator != ',' {
+		opts.DecimalSeparator = '.'
+	}
+	if len(opts.DateLayouts) == 0 {
+		opts.DateLayouts = DefaultDateLayouts
+	}
+
 	return &Reader{
-		filePath: filePath,
+		filePath:         source,
+		open:             open,
+		aliases:          mergeAliases(opts.Aliases),
+		comma:            opts.Comma,
+		charset:          opts.Charset,
+		decimalSeparator: opts.DecimalSeparator,
+		dateLayouts:      opts.DateLayouts,
 	}
 }
 
-// ReadAll lê todo o arquivo CSV e retorna os registros
+// ReadAll lê todo o arquivo CSV e retorna os registros.
+// Para arquivos grandes, prefira Open, que lê uma linha por vez.
 func (r *Reader) ReadAll() ([]*models.Record, []error, error) {
-	file, err := os.Open(r.filePath)
+	return models.ReadAll(r)
+}
+
+// Open abre o arquivo CSV para leitura em streaming, consumindo o cabeçalho.
+// As colunas são localizadas pelo nome no cabeçalho; se faltar alguma coluna
+// obrigatória, Open retorna ErrMissingColumns com todas elas.
+func (r *Reader) Open() (models.RecordStream, error) {
+	file, err := r.open()
 	if err != nil {
-		return nil, nil, fmt.Errorf("erro ao abrir arquivo: %w", err)
+		return nil, err
 	}
-	defer file.Close()
 
-	csvReader := csv.NewReader(file)
-	csvReader.Comma = ','
+	input, comma, err := r.openInput(file)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	csvReader := csv.NewReader(input)
+	csvReader.Comma = comma
 	csvReader.LazyQuotes = true
 	csvReader.TrimLeadingSpace = true
-
-	// Lê todas as linhas
-	rows, err := csvReader.ReadAll()
+	// Linhas com mais ou menos campos que o cabeçalho são tratadas em parseRow,
+	// como erro da própria linha, em vez de encerrar a leitura
+	csvReader.FieldsPerRecord = -1
+	// A linha lida é descartada após o parse, então o buffer pode ser reaproveitado
+	csvReader.ReuseRecord = true
+
+	// O cabeçalho (primeira linha) define a posição de cada coluna
+	header, err := csvReader.Read()
 	if err != nil {
-		return nil, nil, fmt.Errorf("erro ao ler CSV: %w", err)
+		file.Close()
+		if err == io.EOF {
+			return nil, fmt.Errorf("arquivo CSV vazio")
+		}
+		return nil, fmt.Errorf("erro ao ler CSV: %w", err)
 	}
 
-	if len(rows) == 0 {
-		return nil, nil, fmt.Errorf("arquivo CSV vazio")
+	columns, err := newColumnIndex(header, r.aliases)
+	if err != nil {
+		file.Close()
+		return nil, err
 	}
 
-	// Pula o cabeçalho (primeira linha)
-	rows = rows[1:]
+	return &Stream{
+		reader:    r,
+		file:      file,
+		csv:       csvReader,
+		columns:   columns,
+		fields:    len(header),
+		rowNumber: 1,
+	}, nil
+}
 
-	var records []*models.Record
-	var errors []error
+// Stream lê os registros de um CSV uma linha por vez, com uso de memória
+// constante independentemente do tamanho do arquivo
+type Stream struct {
+	reader  *Reader
+	file    io.ReadCloser
+	csv     *csv.Reader
+	columns columnIndex
+	// fields é o número de campos do cabeçalho, exigido em todas as linhas
+	fields    int
+	rowNumber int
+	err       error
+}
 
-	// Processa cada linha
-	for i, row := range rows {
-		rowNumber := i + 2 // +2 porque pulamos header e índice começa em 0
-		record, err := r.parseRow(row, rowNumber)
-		if err != nil {
-			errors = append(errors, err)
-			continue
-		}
-		records = append(records, record)
+// Next retorna o próximo registro. Ao fim do arquivo retorna io.EOF.
+// Uma linha inválida retorna um *models.ValidationError (veja IsParseError) e a
+// leitura pode continuar; qualquer outro erro encerra o stream.
+func (s *Stream) Next() (*models.Record, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	row, err := s.csv.Read()
+	if err == io.EOF {
+		s.err = io.EOF
+		return nil, io.EOF
+	}
+	if err != nil {
+		s.err = fmt.Errorf("erro ao ler CSV: %w", err)
+		return nil, s.err
 	}
 
-	return records, errors, nil
+	s.rowNumber++
+	record, err := s.reader.parseRow(row, s.columns, s.fields, s.rowNumber)
+	return models.WithSource(record, err, s.reader.filePath)
 }
 
-// parseRow converte uma linha do CSV em um Record
-func (r *Reader) parseRow(row []string, rowNumber int) (*models.Record, error) {
-	if len(row) < 7 {
+// Close fecha o arquivo
+func (s *Stream) Close() error {
+	return s.file.Close()
+}
+
+// IsParseError indica se o erro retornado por Stream.Next se refere a uma única
+// linha inválida, e não a uma falha de leitura do arquivo
+func IsParseError(err error) bool {
+	return models.IsValidationError(err)
+}
+
+// parseRow converte uma linha do CSV em um Record (veja models.ParseRecord).
+// Uma linha com mais ou menos campos que o cabeçalho é rejeitada inteira.
+func (r *Reader) parseRow(row []string, columns columnIndex, width, rowNumber int) (*models.Record, error) {
+	if len(row) != width {
+		message := "número insuficiente de colunas"
+		if len(row) > width {
+			message = "número excessivo de colunas"
+		}
 		return nil, &models.ValidationError{
 			RowNumber: rowNumber,
 			Field:     "estrutura",
-			Message:   "número insuficiente de colunas",
+			Code:      models.CodeInvalid,
+			Message:   message,
 			Value:     len(row),
 		}
 	}
 
-	// Nome
-	name := row[0]
-	if name == "" {
-		return nil, &models.ValidationError{
-			RowNumber: rowNumber,
-			Field:     "name",
-			Message:   "nome não pode ser vazio",
-			Value:     name,
-		}
-	}
-
-	// Email
-	email := row[1]
-	if email == "" {
-		return nil, &models.ValidationError{
-			RowNumber: rowNumber,
-			Field:     "email",
-			Message:   "email não pode ser vazio",
-			Value:     email,
-		}
-	}
+	fields := rowFields{reader: r, row: row, columns: columns}
+	return models.ParseRecord(fields, rowNumber, strings.Join(r.dateLayouts, ", "))
+}
 
-	// Age
-	age, err := strconv.Atoi(row[2])
-	if err != nil || age < 0 || age > 150 {
-		return nil, &models.ValidationError{
-			RowNumber: rowNumber,
-			Field:     "age",
-			Message:   "idade inválida (deve ser entre 0 e 150)",
-			Value:     row[2],
-		}
-	}
+// rowFields implementa models.Fields sobre uma linha do CSV, com o separador
+// decimal e os formatos de data do leitor
+type rowFields struct {
+	reader  *Reader
+	row     []string
+	columns columnIndex
+}
 
-	// Salary
-	salary, err := strconv.ParseFloat(row[3], 64)
-	if err != nil || salary < 0 {
-		return nil, &models.ValidationError{
-			RowNumber: rowNumber,
-			Field:     "salary",
-			Message:   "salário inválido (deve ser um número positivo)",
-			Value:     row[3],
-		}
-	}
+func (f rowFields) Text(field string) string {
+	return f.columns.get(f.row, field)
+}
 
-	// Department
-	department := row[4]
-	if department == "" {
-		return nil, &models.ValidationError{
-			RowNumber: rowNumber,
-			Field:     "department",
-			Message:   "departamento não pode ser vazio",
-			Value:     department,
-		}
-	}
+func (f rowFields) Int(field string) (int, bool) {
+	value, err := strconv.Atoi(f.Text(field))
+	return value, err == nil
+}
 
-	// IsActive
-	isActive, err := strconv.ParseBool(row[5])
-	if err != nil {
-		return nil, &models.ValidationError{
-			RowNumber: rowNumber,
-			Field:     "is_active",
-			Message:   "valor inválido (deve ser true ou false)",
-			Value:     row[5],
-		}
-	}
+func (f rowFields) Float(field string) (float64, bool) {
+	value, err := f.reader.parseDecimal(f.Text(field))
+	return value, err == nil
+}
 
-	// CreatedAt
-	createdAt, err := time.Parse("2006-01-02", row[6])
-	if err != nil {
-		return nil, &models.ValidationError{
-			RowNumber: rowNumber,
-			Field:     "created_at",
-			Message:   "data inválida (formato esperado: YYYY-MM-DD)",
-			Value:     row[6],
-		}
-	}
+func (f rowFields) Bool(field string) (bool, bool) {
+	value, err := strconv.ParseBool(f.Text(field))
+	return value, err == nil
+}
 
-	return &models.Record{
-		Name:        name,
-		Email:       email,
-		Age:         age,
-		Salary:      salary,
-		Department:  department,
-		IsActive:    isActive,
-		CreatedAt:   createdAt,
-		ProcessedAt: time.Now(),
-		RowNumber:   rowNumber,
-	}, nil
+func (f rowFields) Date(field string) (time.Time, bool) {
+	value, err := f.reader.parseDate(f.Text(field))
+	return value, err == nil
 }
@@ -1,9 +1,14 @@
 package csvreader
 
 import (
+	"errors"
+	"io"
 	"os"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/seu-usuario/worker-pool-csv-processor/internal/models"
 )
 
 func createTempCSV(content string) (string, error) {
@@ -130,7 +135,9 @@ func TestReadAll_FileNotFound(t *testing.T) {
 
 func TestReadAll_InvalidColumns(t *testing.T) {
 	csvContent := `name,email,age,salary,department,is_active,created_at
-João Silva,joao@empresa.com,28`
+João Silva,joao@empresa.com,28
+Maria Santos,maria@empresa.com,32,6200.00,RH,true,2024-01-17,coluna extra
+Pedro Costa,pedro@empresa.com,45,8500.00,Financeiro,true,2024-01-18`
 
 	filePath, err := createTempCSV(csvContent)
 	if err != nil {
@@ -141,17 +148,47 @@ João Silva,joao@empresa.com,28`
 	reader := NewReader(filePath)
 	records, parseErrors, err := reader.ReadAll()
 
-	// O CSV reader retorna erro quando há número incorreto de campos
-	if err == nil {
-		t.Error("Expected error for invalid number of columns, got nil")
+	// Linhas com colunas a menos ou a mais são erros da linha, e a leitura continua
+	if err != nil {
+		t.Fatalf("Expected no fatal error, got %v", err)
+	}
+	if len(records) != 1 || records[0].RowNumber != 4 {
+		t.Errorf("Expected only the record from row 4, got %d records", len(records))
+	}
+	if len(parseErrors) != 2 {
+		t.Fatalf("Expected 2 parse errors, got %d", len(parseErrors))
 	}
 
-	if len(records) != 0 {
-		t.Errorf("Expected 0 valid records, got %d", len(records))
+	expected := []struct{ row, fields int }{{2, 3}, {3, 8}}
+	for i, want := range expected {
+		var validationErr *models.ValidationError
+		if !errors.As(parseErrors[i], &validationErr) {
+			t.Fatalf("Expected *models.ValidationError, got %T", parseErrors[i])
+		}
+		if validationErr.Field != "estrutura" || validationErr.RowNumber != want.row || validationErr.Value != want.fields {
+			t.Errorf("Unexpected error for row %d: %+v", want.row, validationErr)
+		}
+	}
+}
+
+func TestReadAll_TrimsDepartment(t *testing.T) {
+	csvContent := "name,email,age,salary,department,is_active,created_at\n" +
+		"João Silva,joao@empresa.com,28,5500.00,\"TI \",true,2024-01-15"
+
+	filePath, err := createTempCSV(csvContent)
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(filePath)
+
+	records, parseErrors, err := NewReader(filePath).ReadAll()
+	if err != nil || len(parseErrors) != 0 {
+		t.Fatalf("Expected no errors, got %v / %v", err, parseErrors)
+	}
+	// O departamento é comparado com o cadastro pelo nome, sem os espaços
+	if len(records) != 1 || records[0].Department != "TI" {
+		t.Errorf("Expected department %q, got %+v", "TI", records)
 	}
-	
-	// parseErrors pode estar vazio se o erro ocorreu na leitura do CSV
-	_ = parseErrors
 }
 
 func TestReadAll_InvalidAge(t *testing.T) {
@@ -295,6 +332,48 @@ João Silva,joao@empresa.com,invalid,not_number,TI,maybe,invalid-date
 	}
 }
 
+func TestReadAll_AllFieldErrorsInRow(t *testing.T) {
+	csvContent := `name,email,age,salary,department,is_active,created_at
+João Silva,joao@empresa.com,abc,5500,TI,true,15/13/2024`
+
+	filePath, err := createTempCSV(csvContent)
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(filePath)
+
+	_, parseErrors, err := NewReader(filePath).ReadAll()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(parseErrors) != 1 {
+		t.Fatalf("Expected 1 parse error (one per row), got %d", len(parseErrors))
+	}
+
+	var validationErrs models.ValidationErrors
+	if !errors.As(parseErrors[0], &validationErrs) {
+		t.Fatalf("Expected models.ValidationErrors, got %T", parseErrors[0])
+	}
+	if len(validationErrs) != 2 {
+		t.Fatalf("Expected 2 field errors, got %v", validationErrs)
+	}
+	expected := []struct {
+		field string
+		value string
+	}{{"age", "abc"}, {"created_at", "15/13/2024"}}
+	for i, e := range expected {
+		if validationErrs[i].Field != e.field || validationErrs[i].Value != e.value || validationErrs[i].RowNumber != 2 {
+			t.Errorf("Expected error on field %q with value %q, got %+v", e.field, e.value, validationErrs[i])
+		}
+		if validationErrs[i].Code != models.CodeInvalid {
+			t.Errorf("Expected code %q, got %q", models.CodeInvalid, validationErrs[i].Code)
+		}
+		if validationErrs[i].Source != filePath {
+			t.Errorf("Expected source %q, got %q", filePath, validationErrs[i].Source)
+		}
+	}
+}
+
 func TestReadAll_MixedValidAndInvalid(t *testing.T) {
 	csvContent := `name,email,age,salary,department,is_active,created_at
 João Silva,joao@empresa.com,28,5500.00,TI,true,2024-01-15
@@ -350,3 +429,105 @@ João Silva,joao@empresa.com,28,5500.00,TI,true,2024-01-15`
 	}
 }
 
+func TestOpen_StreamsRecordsAndParseErrors(t *testing.T) {
+	csvContent := `name,email,age,salary,department,is_active,created_at
+João Silva,joao@empresa.com,28,5500.00,TI,true,2024-01-15
+Invalid Age,invalid@empresa.com,abc,5500.00,TI,true,2024-01-16
+Maria Santos,maria@empresa.com,32,6200.00,RH,true,2024-01-17`
+
+	filePath, err := createTempCSV(csvContent)
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(filePath)
+
+	stream, err := NewReader(filePath).Open()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer stream.Close()
+
+	var rows []int
+	var parseErrors int
+	for {
+		record, err := stream.Next()
+		if err == io.EOF {
+			break
+		}
+		if IsParseError(err) {
+			parseErrors++
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Expected no fatal error, got %v", err)
+		}
+		rows = append(rows, record.RowNumber)
+	}
+
+	if len(rows) != 2 || rows[0] != 2 || rows[1] != 4 {
+		t.Errorf("Expected records from rows [2 4], got %v", rows)
+	}
+	if parseErrors != 1 {
+		t.Errorf("Expected 1 parse error, got %d", parseErrors)
+	}
+
+	// Após o fim, Next continua retornando io.EOF
+	if _, err := stream.Next(); err != io.EOF {
+		t.Errorf("Expected io.EOF after end of stream, got %v", err)
+	}
+}
+
+// failingReader entrega o conteúdo e depois falha, simulando um erro de leitura
+type failingReader struct {
+	content io.Reader
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	n, err := r.content.Read(p)
+	if err == io.EOF {
+		return n, errors.New("falha de disco")
+	}
+	return n, err
+}
+
+func (r *failingReader) Close() error {
+	return nil
+}
+
+func TestOpen_FatalReadError(t *testing.T) {
+	// Linhas suficientes para que a falha ocorra depois do cabeçalho
+	csvContent := "name,email,age,salary,department,is_active,created_at\n" +
+		strings.Repeat("João Silva,joao@empresa.com,28,5500.00,TI,true,2024-01-15\n", 1000)
+
+	reader := NewReaderFromSource("falha.csv", func() (io.ReadCloser, error) {
+		return &failingReader{content: strings.NewReader(csvContent)}, nil
+	}, Options{})
+
+	stream, err := reader.Open()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer stream.Close()
+
+	for err == nil {
+		_, err = stream.Next()
+	}
+	if err == io.EOF || IsParseError(err) {
+		t.Fatalf("Expected fatal read error, got %v", err)
+	}
+	if _, again := stream.Next(); again != err {
+		t.Errorf("Expected the same error on subsequent calls, got %v", again)
+	}
+}
+
+func TestOpen_NoHeader(t *testing.T) {
+	filePath, err := createTempCSV("")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(filePath)
+
+	if _, err := NewReader(filePath).Open(); err == nil {
+		t.Error("Expected error for empty file, got nil")
+	}
+}
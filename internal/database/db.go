@@ -1,11 +1,14 @@
 package database
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
-	_ "github.com/mattn/go-sqlite3"
+	"github.com/mattn/go-sqlite3"
 
 	"github.com/seu-usuario/worker-pool-csv-processor/internal/models"
 )
@@ -15,9 +18,10 @@ type DB struct {
 	conn *sql.DB
 }
 
-// NewDB cria uma nova instância do banco de dados
+// NewDB cria uma nova instância do banco de dados. dbPath pode ser um caminho
+// ou uma URI file:, com ou sem parâmetros do driver.
 func NewDB(dbPath string) (*DB, error) {
-	conn, err := sql.Open("sqlite3", dbPath+"?_foreign_keys=1")
+	conn, err := sql.Open("sqlite3", dataSourceName(dbPath))
 	if err != nil {
 		return nil, fmt.Errorf("erro ao abrir banco de dados: %w", err)
 	}
@@ -36,66 +40,149 @@ func NewDB(dbPath string) (*DB, error) {
 	return db, nil
 }
 
-// createTables cria as tabelas necessárias
-func (d *DB) createTables() error {
-	query := `
-	CREATE TABLE IF NOT EXISTS employees (
+// dataSourceName habilita as chaves estrangeiras em todas as conexões,
+// preservando os parâmetros que já estiverem em dbPath
+func dataSourceName(dbPath string) string {
+	separator := "?"
+	if strings.Contains(dbPath, "?") {
+		separator = "&"
+	}
+	return dbPath + separator + "_foreign_keys=1"
+}
+
+// employeesSchema é a definição da tabela de funcionários; %s é o nome da tabela
+const employeesSchema = `
+	CREATE TABLE IF NOT EXISTS %s (
 		id INTEGER PRIMARY KEY AUTOINCREMENT,
 		name TEXT NOT NULL,
 		email TEXT UNIQUE NOT NULL,
 		age INTEGER NOT NULL,
 		salary REAL NOT NULL,
-		department TEXT NOT NULL,
+		department_id INTEGER NOT NULL REFERENCES departments(id),
 		is_active BOOLEAN NOT NULL,
 		created_at TIMESTAMP NOT NULL,
 		processed_at TIMESTAMP NOT NULL,
 		row_number INTEGER,
 		created_at_db TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 	);
+`
 
+// employeesIndexes são os índices da tabela de funcionários
+const employeesIndexes = `
 	CREATE INDEX IF NOT EXISTS idx_email ON employees(email);
-	CREATE INDEX IF NOT EXISTS idx_department ON employees(department);
+	CREATE INDEX IF NOT EXISTS idx_department ON employees(department_id);
 	CREATE INDEX IF NOT EXISTS idx_is_active ON employees(is_active);
-	`
+`
 
-	_, err := d.conn.Exec(query)
+// createTables cria as tabelas necessárias
+func (d *DB) createTables() error {
+	if err := d.createDepartmentsTable(); err != nil {
+		return err
+	}
+
+	if _, err := d.conn.Exec(fmt.Sprintf(employeesSchema, "employees")); err != nil {
+		return err
+	}
+	if err := d.migrateDepartmentColumn(); err != nil {
+		return fmt.Errorf("erro ao migrar departamentos: %w", err)
+	}
+
+	_, err := d.conn.Exec(employeesIndexes)
 	return err
 }
 
+// migrateDepartmentColumn converte bancos criados antes do cadastro de
+// departamentos, em que employees.department era texto livre: cada nome é
+// cadastrado em departments e a coluna é trocada pela chave department_id
+func (d *DB) migrateDepartmentColumn() error {
+	var legacy int
+	err := d.conn.QueryRow("SELECT COUNT(*) FROM pragma_table_info('employees') WHERE name = 'department'").Scan(&legacy)
+	if err != nil || legacy == 0 {
+		return err
+	}
+
+	tx, err := d.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	statements := []string{
+		`INSERT OR IGNORE INTO departments (name) SELECT DISTINCT department FROM employees`,
+		fmt.Sprintf(employeesSchema, "employees_new"),
+		`INSERT INTO employees_new (id, name, email, age, salary, department_id, is_active, created_at, processed_at, row_number, created_at_db)
+		SELECT e.id, e.name, e.email, e.age, e.salary, dep.id, e.is_active, e.created_at, e.processed_at, e.row_number, e.created_at_db
+		FROM employees e JOIN departments dep ON dep.name = e.department`,
+		`DROP TABLE employees`,
+		`ALTER TABLE employees_new RENAME TO employees`,
+	}
+	for _, statement := range statements {
+		if _, err := tx.Exec(statement); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
 // InsertRecord insere um registro no banco de dados
 func (d *DB) InsertRecord(record *models.Record) error {
+	return d.InsertRecordContext(context.Background(), record)
+}
+
+// InsertRecordContext insere um registro respeitando o cancelamento do contexto.
+// O departamento é vinculado pelo nome; se não estiver cadastrado e ativo,
+// retorna ErrDepartmentNotFound.
+func (d *DB) InsertRecordContext(ctx context.Context, record *models.Record) error {
 	query := `
-	INSERT INTO employees (name, email, age, salary, department, is_active, created_at, processed_at, row_number)
-	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	INSERT INTO employees (name, email, age, salary, department_id, is_active, created_at, processed_at, row_number)
+	SELECT ?, ?, ?, ?, id, ?, ?, ?, ?
+	FROM departments
+	WHERE name = ? AND active = 1
 	ON CONFLICT(email) DO UPDATE SET
 		name = excluded.name,
 		age = excluded.age,
 		salary = excluded.salary,
-		department = excluded.department,
+		department_id = excluded.department_id,
 		is_active = excluded.is_active,
 		processed_at = excluded.processed_at
 	`
 
-	_, err := d.conn.Exec(
+	result, err := d.conn.ExecContext(
+		ctx,
 		query,
 		record.Name,
 		record.Email,
 		record.Age,
 		record.Salary,
-		record.Department,
 		record.IsActive,
 		record.CreatedAt,
 		record.ProcessedAt,
 		record.RowNumber,
+		record.Department,
 	)
 
 	if err != nil {
 		return fmt.Errorf("erro ao inserir registro: %w", err)
 	}
 
+	// Sem departamento ativo correspondente, o SELECT não retorna linhas e nada é inserido
+	if inserted, err := result.RowsAffected(); err == nil && inserted == 0 {
+		return fmt.Errorf("erro ao inserir registro: %w: %s", ErrDepartmentNotFound, record.Department)
+	}
+
 	return nil
 }
 
+// IsTransient indica se o erro é transitório (banco ocupado ou bloqueado)
+// e a operação pode ser repetida
+func IsTransient(err error) bool {
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.Code == sqlite3.ErrBusy || sqliteErr.Code == sqlite3.ErrLocked
+	}
+	return false
+}
+
 // GetStats retorna estatísticas do banco de dados
 func (d *DB) GetStats() (map[string]interface{}, error) {
 	stats := make(map[string]interface{})
@@ -110,9 +197,10 @@ func (d *DB) GetStats() (map[string]interface{}, error) {
 
 	// Total por departamento
 	rows, err := d.conn.Query(`
-		SELECT department, COUNT(*) as count 
-		FROM employees 
-		GROUP BY department
+		SELECT dep.name, COUNT(*) as count
+		FROM employees e
+		JOIN departments dep ON dep.id = e.department_id
+		GROUP BY dep.name
 	`)
 	if err != nil {
 		return nil, err
@@ -156,9 +244,10 @@ func (d *DB) Cleanup() error {
 // GetRecordByEmail busca um registro por email
 func (d *DB) GetRecordByEmail(email string) (*models.Record, error) {
 	query := `
-		SELECT id, name, email, age, salary, department, is_active, created_at, processed_at, row_number
-		FROM employees
-		WHERE email = ?
+		SELECT e.id, e.name, e.email, e.age, e.salary, dep.name, e.department_id, e.is_active, e.created_at, e.processed_at, e.row_number
+		FROM employees e
+		JOIN departments dep ON dep.id = e.department_id
+		WHERE e.email = ?
 	`
 
 	var record models.Record
@@ -171,6 +260,7 @@ func (d *DB) GetRecordByEmail(email string) (*models.Record, error) {
 		&record.Age,
 		&record.Salary,
 		&record.Department,
+		&record.DepartmentID,
 		&record.IsActive,
 		&createdAtStr,
 		&processedAtStr,
@@ -1,10 +1,15 @@
 package database
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"os"
 	"testing"
 	"time"
 
+	"github.com/mattn/go-sqlite3"
+
 	"github.com/seu-usuario/worker-pool-csv-processor/internal/models"
 )
 
@@ -34,6 +39,27 @@ func TestNewDB(t *testing.T) {
 	}
 }
 
+func TestNewDB_DataSourceName(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, dbPath := range []string{
+		"file:" + dir + "/uri.db",
+		"file:" + dir + "/params.db?_busy_timeout=5000",
+		dir + "/params.db?mode=rwc",
+	} {
+		db, err := NewDB(dbPath)
+		if err != nil {
+			t.Errorf("Expected no error for %s, got %v", dbPath, err)
+			continue
+		}
+		var enabled int
+		if err := db.conn.QueryRow("PRAGMA foreign_keys").Scan(&enabled); err != nil || enabled != 1 {
+			t.Errorf("Expected foreign keys enabled for %s, got %d (err %v)", dbPath, enabled, err)
+		}
+		db.Close()
+	}
+}
+
 func TestCreateTables(t *testing.T) {
 	db, filePath := createTestDB(t)
 	defer os.Remove(filePath)
@@ -351,3 +377,55 @@ func TestGetStats_EmptyDatabase(t *testing.T) {
 		t.Errorf("Expected active 0, got %d", stats["active"])
 	}
 }
+
+func TestInsertRecordContext_Cancelled(t *testing.T) {
+	db, filePath := createTestDB(t)
+	defer os.Remove(filePath)
+	defer db.Close()
+
+	record := &models.Record{
+		Name:        "João Silva",
+		Email:       "joao@empresa.com",
+		Age:         28,
+		Salary:      5500.00,
+		Department:  "TI",
+		IsActive:    true,
+		CreatedAt:   time.Now(),
+		ProcessedAt: time.Now(),
+		RowNumber:   1,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := db.InsertRecordContext(ctx, record); err == nil {
+		t.Error("Expected error inserting with cancelled context, got nil")
+	}
+
+	stats, _ := db.GetStats()
+	if stats["total"].(int) != 0 {
+		t.Errorf("Expected 0 records, got %d", stats["total"])
+	}
+}
+
+func TestIsTransient(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"Nil error", nil, false},
+		{"Generic error", errors.New("boom"), false},
+		{"Busy", sqlite3.Error{Code: sqlite3.ErrBusy}, true},
+		{"Locked wrapped", fmt.Errorf("erro ao inserir registro: %w", sqlite3.Error{Code: sqlite3.ErrLocked}), true},
+		{"Constraint", sqlite3.Error{Code: sqlite3.ErrConstraint}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsTransient(tt.err); got != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
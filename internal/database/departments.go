@@ -0,0 +1,177 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/seu-usuario/worker-pool-csv-processor/internal/models"
+)
+
+var (
+	ErrDepartmentNotFound = errors.New("departamento não cadastrado")
+	ErrDepartmentExists   = errors.New("departamento já cadastrado")
+	ErrDepartmentRetired  = errors.New("departamento já desativado")
+)
+
+// Department é um departamento do cadastro. Departamentos não são apagados:
+// um departamento desativado deixa de ser aceito em novas importações, mas os
+// funcionários já vinculados a ele são mantidos.
+type Department struct {
+	ID        int
+	Name      string
+	Active    bool
+	CreatedAt time.Time
+	// RetiredAt é o momento da desativação (zero se ativo)
+	RetiredAt time.Time
+}
+
+// createDepartmentsTable cria a tabela de departamentos e, se ela estiver vazia,
+// cadastra models.DefaultDepartments
+func (d *DB) createDepartmentsTable() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS departments (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT UNIQUE NOT NULL,
+		active BOOLEAN NOT NULL DEFAULT 1,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		retired_at TIMESTAMP
+	);
+	`
+	if _, err := d.conn.Exec(query); err != nil {
+		return err
+	}
+
+	var count int
+	if err := d.conn.QueryRow("SELECT COUNT(*) FROM departments").Scan(&count); err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	tx, err := d.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, name := range models.DefaultDepartments {
+		if _, err := tx.Exec("INSERT INTO departments (name) VALUES (?)", name); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// AddDepartment cadastra um departamento ou reativa um departamento desativado.
+// Um departamento já ativo retorna ErrDepartmentExists.
+func (d *DB) AddDepartment(name string) (*Department, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("nome do departamento não pode ser vazio")
+	}
+
+	existing, err := d.GetDepartment(name)
+	switch {
+	case errors.Is(err, ErrDepartmentNotFound):
+		if _, err := d.conn.Exec("INSERT INTO departments (name) VALUES (?)", name); err != nil {
+			return nil, fmt.Errorf("erro ao cadastrar departamento: %w", err)
+		}
+	case err != nil:
+		return nil, err
+	case existing.Active:
+		return nil, fmt.Errorf("%w: %s", ErrDepartmentExists, name)
+	default:
+		if _, err := d.conn.Exec("UPDATE departments SET active = 1, retired_at = NULL WHERE id = ?", existing.ID); err != nil {
+			return nil, fmt.Errorf("erro ao reativar departamento: %w", err)
+		}
+	}
+
+	return d.GetDepartment(name)
+}
+
+// RetireDepartment desativa um departamento
+func (d *DB) RetireDepartment(name string) error {
+	department, err := d.GetDepartment(strings.TrimSpace(name))
+	if err != nil {
+		return err
+	}
+	if !department.Active {
+		return fmt.Errorf("%w: %s", ErrDepartmentRetired, department.Name)
+	}
+
+	_, err = d.conn.Exec("UPDATE departments SET active = 0, retired_at = ? WHERE id = ?", time.Now(), department.ID)
+	if err != nil {
+		return fmt.Errorf("erro ao desativar departamento: %w", err)
+	}
+	return nil
+}
+
+// GetDepartment busca um departamento pelo nome exato
+func (d *DB) GetDepartment(name string) (*Department, error) {
+	row := d.conn.QueryRow(`
+		SELECT id, name, active, created_at, retired_at
+		FROM departments
+		WHERE name = ?
+	`, name)
+
+	department, err := scanDepartment(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", ErrDepartmentNotFound, name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar departamento: %w", err)
+	}
+	return department, nil
+}
+
+// ListDepartments retorna todos os departamentos, ativos e desativados, por nome
+func (d *DB) ListDepartments() ([]*Department, error) {
+	rows, err := d.conn.Query(`
+		SELECT id, name, active, created_at, retired_at
+		FROM departments
+		ORDER BY name
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar departamentos: %w", err)
+	}
+	defer rows.Close()
+
+	var departments []*Department
+	for rows.Next() {
+		department, err := scanDepartment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao listar departamentos: %w", err)
+		}
+		departments = append(departments, department)
+	}
+	return departments, rows.Err()
+}
+
+// IsActiveDepartment indica se o departamento está cadastrado e ativo.
+// Implementa validator.DepartmentLookup.
+func (d *DB) IsActiveDepartment(name string) (bool, error) {
+	var active bool
+	err := d.conn.QueryRow("SELECT active FROM departments WHERE name = ?", name).Scan(&active)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("erro ao consultar departamento: %w", err)
+	}
+	return active, nil
+}
+
+// scanDepartment lê um departamento de uma linha de SELECT id, name, active, created_at, retired_at
+func scanDepartment(row interface{ Scan(...any) error }) (*Department, error) {
+	var department Department
+	var retiredAt sql.NullTime
+	if err := row.Scan(&department.ID, &department.Name, &department.Active, &department.CreatedAt, &retiredAt); err != nil {
+		return nil, err
+	}
+	department.RetiredAt = retiredAt.Time
+	return &department, nil
+}
@@ -0,0 +1,204 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/seu-usuario/worker-pool-csv-processor/internal/models"
+)
+
+func TestNewDB_SeedsDefaultDepartments(t *testing.T) {
+	db, filePath := createTestDB(t)
+	defer os.Remove(filePath)
+	defer db.Close()
+
+	departments, err := db.ListDepartments()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(departments) != len(models.DefaultDepartments) {
+		t.Fatalf("Expected %d departments, got %d", len(models.DefaultDepartments), len(departments))
+	}
+	for _, department := range departments {
+		if !department.Active || department.CreatedAt.IsZero() {
+			t.Errorf("Expected active department with creation date, got %+v", department)
+		}
+	}
+}
+
+func TestDepartments_AddRetireReactivate(t *testing.T) {
+	db, filePath := createTestDB(t)
+	defer os.Remove(filePath)
+	defer db.Close()
+
+	department, err := db.AddDepartment(" Logística ")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if department.Name != "Logística" || !department.Active || department.ID == 0 {
+		t.Errorf("Unexpected department: %+v", department)
+	}
+	if _, err := db.AddDepartment("Logística"); !errors.Is(err, ErrDepartmentExists) {
+		t.Errorf("Expected ErrDepartmentExists, got %v", err)
+	}
+
+	if err := db.RetireDepartment("Logística"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if active, err := db.IsActiveDepartment("Logística"); err != nil || active {
+		t.Errorf("Expected retired department, got active=%v err=%v", active, err)
+	}
+	retired, err := db.GetDepartment("Logística")
+	if err != nil || retired.RetiredAt.IsZero() {
+		t.Errorf("Expected retirement date, got %+v (err %v)", retired, err)
+	}
+	if err := db.RetireDepartment("Logística"); !errors.Is(err, ErrDepartmentRetired) {
+		t.Errorf("Expected ErrDepartmentRetired, got %v", err)
+	}
+
+	// Cadastrar de novo reativa o mesmo departamento
+	reactivated, err := db.AddDepartment("Logística")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if reactivated.ID != department.ID || !reactivated.Active || !reactivated.RetiredAt.IsZero() {
+		t.Errorf("Expected reactivated department %d, got %+v", department.ID, reactivated)
+	}
+}
+
+func TestDepartments_NotFound(t *testing.T) {
+	db, filePath := createTestDB(t)
+	defer os.Remove(filePath)
+	defer db.Close()
+
+	if _, err := db.GetDepartment("Inexistente"); !errors.Is(err, ErrDepartmentNotFound) {
+		t.Errorf("Expected ErrDepartmentNotFound, got %v", err)
+	}
+	if err := db.RetireDepartment("Inexistente"); !errors.Is(err, ErrDepartmentNotFound) {
+		t.Errorf("Expected ErrDepartmentNotFound, got %v", err)
+	}
+	if active, err := db.IsActiveDepartment("Inexistente"); err != nil || active {
+		t.Errorf("Expected inactive without error, got active=%v err=%v", active, err)
+	}
+	if _, err := db.AddDepartment("  "); err == nil {
+		t.Error("Expected error for empty name, got nil")
+	}
+}
+
+func TestInsertRecord_LinksDepartment(t *testing.T) {
+	db, filePath := createTestDB(t)
+	defer os.Remove(filePath)
+	defer db.Close()
+
+	record := &models.Record{
+		Name:        "João Silva",
+		Email:       "joao@empresa.com",
+		Age:         28,
+		Salary:      5500.00,
+		Department:  "Vendas",
+		IsActive:    true,
+		CreatedAt:   time.Now(),
+		ProcessedAt: time.Now(),
+		RowNumber:   1,
+	}
+	if err := db.InsertRecord(record); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	retrieved, err := db.GetRecordByEmail("joao@empresa.com")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	department, _ := db.GetDepartment("Vendas")
+	if retrieved.Department != "Vendas" || retrieved.DepartmentID != department.ID {
+		t.Errorf("Expected department Vendas (%d), got %s (%d)", department.ID, retrieved.Department, retrieved.DepartmentID)
+	}
+
+	// Um departamento fora do cadastro não é inserido
+	record.Email = "maria@empresa.com"
+	record.Department = "Inexistente"
+	if err := db.InsertRecord(record); !errors.Is(err, ErrDepartmentNotFound) {
+		t.Errorf("Expected ErrDepartmentNotFound, got %v", err)
+	}
+	if _, err := db.GetRecordByEmail("maria@empresa.com"); err == nil {
+		t.Error("Expected record not to be inserted")
+	}
+
+	// Nem um departamento desativado depois da validação
+	if err := db.RetireDepartment("Vendas"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	record.Department = "Vendas"
+	if err := db.InsertRecord(record); !errors.Is(err, ErrDepartmentNotFound) {
+		t.Errorf("Expected ErrDepartmentNotFound for a retired department, got %v", err)
+	}
+}
+
+func TestNewDB_MigratesLegacyDepartmentColumn(t *testing.T) {
+	_, filePath := createTestDB(t)
+	os.Remove(filePath)
+	defer os.Remove(filePath)
+
+	// Banco no esquema antigo, com o departamento em texto livre
+	legacy, err := sql.Open("sqlite3", filePath)
+	if err != nil {
+		t.Fatalf("Failed to open legacy database: %v", err)
+	}
+	_, err = legacy.Exec(`
+		CREATE TABLE employees (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL,
+			email TEXT UNIQUE NOT NULL,
+			age INTEGER NOT NULL,
+			salary REAL NOT NULL,
+			department TEXT NOT NULL,
+			is_active BOOLEAN NOT NULL,
+			created_at TIMESTAMP NOT NULL,
+			processed_at TIMESTAMP NOT NULL,
+			row_number INTEGER,
+			created_at_db TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE INDEX idx_department ON employees(department);
+		INSERT INTO employees (name, email, age, salary, department, is_active, created_at, processed_at, row_number) VALUES
+			('João', 'joao@test.com', 28, 5000, 'TI', 1, '2024-01-15 00:00:00', '2024-01-15 00:00:00', 1),
+			('Ana', 'ana@test.com', 35, 7000, 'Compras', 1, '2024-01-15 00:00:00', '2024-01-15 00:00:00', 2);
+	`)
+	legacy.Close()
+	if err != nil {
+		t.Fatalf("Failed to create legacy schema: %v", err)
+	}
+
+	db, err := NewDB(filePath)
+	if err != nil {
+		t.Fatalf("Expected migration to succeed, got %v", err)
+	}
+	defer db.Close()
+
+	stats, err := db.GetStats()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	byDept := stats["by_department"].(map[string]int)
+	if stats["total"].(int) != 2 || byDept["TI"] != 1 || byDept["Compras"] != 1 {
+		t.Errorf("Expected migrated records, got %v", stats)
+	}
+
+	// Departamentos que só existiam nos dados passam a ser cadastrados
+	if _, err := db.GetDepartment("Compras"); err != nil {
+		t.Errorf("Expected legacy department to be registered, got %v", err)
+	}
+
+	// A migração roda uma única vez
+	db.Close()
+	db, err = NewDB(filePath)
+	if err != nil {
+		t.Fatalf("Expected reopening to succeed, got %v", err)
+	}
+	defer db.Close()
+	if stats, _ := db.GetStats(); stats["total"].(int) != 2 {
+		t.Errorf("Expected 2 records after reopening, got %v", stats["total"])
+	}
+}
@@ -0,0 +1,211 @@
+package input
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+)
+
+// Compression identifica a compressão de um arquivo de entrada
+type Compression string
+
+const (
+	CompressionNone Compression = ""
+	CompressionGzip Compression = "gzip"
+	CompressionZstd Compression = "zstd"
+	CompressionZip  Compression = "zip"
+)
+
+var (
+	ErrUnsupportedCompression = errors.New("compressão não suportada")
+	ErrArchive                = errors.New("arquivo zip pode conter vários arquivos; use Entries")
+)
+
+// ZstdDecoder cria o leitor de conteúdo zstd. A biblioteca padrão do Go não
+// inclui zstd: sem um decoder registrado (por exemplo, um adaptador para
+// github.com/klauspost/compress/zstd), arquivos zstd retornam ErrUnsupportedCompression.
+var ZstdDecoder func(r io.Reader) (io.ReadCloser, error)
+
+// magicNumbers são as assinaturas reconhecidas no início do arquivo
+var magicNumbers = []struct {
+	magic       []byte
+	compression Compression
+}{
+	{[]byte{0x1F, 0x8B}, CompressionGzip},
+	{[]byte{0x28, 0xB5, 0x2F, 0xFD}, CompressionZstd},
+	{[]byte("PK\x03\x04"), CompressionZip},
+	{[]byte("PK\x05\x06"), CompressionZip}, // zip vazio
+}
+
+// Detect identifica a compressão pelos primeiros bytes do conteúdo
+func Detect(header []byte) Compression {
+	for _, m := range magicNumbers {
+		if bytes.HasPrefix(header, m.magic) {
+			return m.compression
+		}
+	}
+	return CompressionNone
+}
+
+// Open abre um arquivo, descompactando gzip ou zstd durante a leitura.
+// Arquivos zip retornam ErrArchive, pois devem ser lidos com Entries.
+func Open(filePath string) (io.ReadCloser, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao abrir arquivo: %w", err)
+	}
+
+	content, err := decompress(file)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return content, nil
+}
+
+// decompress detecta a compressão de r e retorna o conteúdo descompactado.
+// Fechar o resultado fecha também r.
+func decompress(r io.ReadCloser) (io.ReadCloser, error) {
+	buffered := bufio.NewReader(r)
+	// Um erro aqui significa apenas que o conteúdo é menor que a assinatura
+	header, _ := buffered.Peek(4)
+
+	switch Detect(header) {
+	case CompressionGzip:
+		gz, err := gzip.NewReader(buffered)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao ler gzip: %w", err)
+		}
+		return &readCloser{Reader: gz, closers: []io.Closer{gz, r}}, nil
+
+	case CompressionZstd:
+		if ZstdDecoder == nil {
+			return nil, fmt.Errorf("%w: zstd (descompacte com 'zstd -d' antes de processar)", ErrUnsupportedCompression)
+		}
+		zr, err := ZstdDecoder(buffered)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao ler zstd: %w", err)
+		}
+		return &readCloser{Reader: zr, closers: []io.Closer{zr, r}}, nil
+
+	case CompressionZip:
+		return nil, ErrArchive
+
+	default:
+		return &readCloser{Reader: buffered, closers: []io.Closer{r}}, nil
+	}
+}
+
+// readCloser combina o leitor descompactado com o fechamento de todas as camadas
+type readCloser struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (rc *readCloser) Close() error {
+	var errs []error
+	for _, c := range rc.closers {
+		errs = append(errs, c.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// Entry é um arquivo de dados da entrada: o próprio arquivo ou um item de um zip
+type Entry struct {
+	// Name identifica a origem dos registros: o caminho do arquivo ou,
+	// em um zip, o nome do item dentro dele
+	Name string
+	// Archive é o caminho do zip que contém o item (vazio fora de um zip)
+	Archive string
+	// Open abre o conteúdo já descompactado
+	Open func() (io.ReadCloser, error)
+}
+
+// Entries lista os arquivos de dados de filePath. Um zip gera uma entrada por
+// arquivo contido, ignorando diretórios e metadados ocultos; gzip, zstd e
+// arquivos sem compressão geram uma única entrada.
+func Entries(filePath string) ([]Entry, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao abrir arquivo: %w", err)
+	}
+	header := make([]byte, 4)
+	n, _ := io.ReadFull(file, header)
+	file.Close()
+
+	if Detect(header[:n]) != CompressionZip {
+		return []Entry{{
+			Name: filePath,
+			Open: func() (io.ReadCloser, error) { return Open(filePath) },
+		}}, nil
+	}
+
+	archive, err := zip.OpenReader(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao abrir zip: %w", err)
+	}
+	defer archive.Close()
+
+	var entries []Entry
+	for _, item := range archive.File {
+		name := item.Name
+		if item.FileInfo().IsDir() || strings.HasPrefix(name, "__MACOSX/") || strings.HasPrefix(path.Base(name), ".") {
+			continue
+		}
+		entries = append(entries, Entry{
+			Name:    name,
+			Archive: filePath,
+			Open:    func() (io.ReadCloser, error) { return openZipEntry(filePath, name) },
+		})
+	}
+	return entries, nil
+}
+
+// openZipEntry abre um item do zip. Cada item reabre o arquivo, então os itens
+// podem ser lidos em qualquer ordem e fechados de forma independente.
+func openZipEntry(archivePath, name string) (io.ReadCloser, error) {
+	archive, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao abrir zip: %w", err)
+	}
+
+	for _, item := range archive.File {
+		if item.Name != name {
+			continue
+		}
+		content, err := item.Open()
+		if err != nil {
+			archive.Close()
+			return nil, fmt.Errorf("erro ao abrir %s: %w", name, err)
+		}
+		// Itens também podem estar compactados (ex.: dados.csv.gz dentro do zip)
+		decompressed, err := decompress(&readCloser{Reader: content, closers: []io.Closer{content, archive}})
+		if err != nil {
+			content.Close()
+			archive.Close()
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		return decompressed, nil
+	}
+
+	archive.Close()
+	return nil, fmt.Errorf("erro ao abrir zip: item %s não encontrado", name)
+}
+
+// TrimCompressionExt remove a extensão de compressão do nome, para que o
+// formato possa ser deduzido (ex.: "employees.csv.gz" → "employees.csv")
+func TrimCompressionExt(name string) string {
+	for _, ext := range []string{".gz", ".gzip", ".zst", ".zstd"} {
+		if strings.HasSuffix(strings.ToLower(name), ext) {
+			return name[:len(name)-len(ext)]
+		}
+	}
+	return name
+}
@@ -0,0 +1,211 @@
+package input
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testContent = "name,email\nJoão,joao@empresa.com\n"
+
+// gzipBytes compacta o conteúdo com gzip
+func gzipBytes(t *testing.T, content string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write([]byte(content)); err != nil {
+		t.Fatalf("Failed to write gzip: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("Failed to close gzip: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// writeFile grava o conteúdo em um arquivo temporário com o nome informado
+func writeFile(t *testing.T, name string, content []byte) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	return path
+}
+
+// readEntry lê todo o conteúdo de uma entrada
+func readEntry(t *testing.T, open func() (io.ReadCloser, error)) string {
+	t.Helper()
+
+	r, err := open()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer r.Close()
+
+	content, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("Failed to read content: %v", err)
+	}
+	return string(content)
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		header   []byte
+		expected Compression
+	}{
+		{[]byte{0x1F, 0x8B, 0x08, 0x00}, CompressionGzip},
+		{[]byte{0x28, 0xB5, 0x2F, 0xFD}, CompressionZstd},
+		{[]byte("PK\x03\x04"), CompressionZip},
+		{[]byte("name"), CompressionNone},
+		{[]byte{0x1F}, CompressionNone},
+		{nil, CompressionNone},
+	}
+
+	for _, tt := range tests {
+		if got := Detect(tt.header); got != tt.expected {
+			t.Errorf("Detect(%v) = %q, expected %q", tt.header, got, tt.expected)
+		}
+	}
+}
+
+func TestOpen_Plain(t *testing.T) {
+	path := writeFile(t, "employees.csv", []byte(testContent))
+
+	if got := readEntry(t, func() (io.ReadCloser, error) { return Open(path) }); got != testContent {
+		t.Errorf("Expected %q, got %q", testContent, got)
+	}
+}
+
+func TestOpen_Gzip(t *testing.T) {
+	// A extensão não importa: a compressão é detectada pelo conteúdo
+	path := writeFile(t, "employees.csv", gzipBytes(t, testContent))
+
+	if got := readEntry(t, func() (io.ReadCloser, error) { return Open(path) }); got != testContent {
+		t.Errorf("Expected %q, got %q", testContent, got)
+	}
+}
+
+func TestOpen_ZstdWithoutDecoder(t *testing.T) {
+	path := writeFile(t, "employees.csv.zst", []byte{0x28, 0xB5, 0x2F, 0xFD, 0x00})
+
+	if _, err := Open(path); !errors.Is(err, ErrUnsupportedCompression) {
+		t.Errorf("Expected ErrUnsupportedCompression, got %v", err)
+	}
+}
+
+func TestOpen_ZstdDecoder(t *testing.T) {
+	path := writeFile(t, "employees.csv.zst", append([]byte{0x28, 0xB5, 0x2F, 0xFD}, testContent...))
+
+	// Decoder falso: descarta a assinatura e devolve o restante
+	ZstdDecoder = func(r io.Reader) (io.ReadCloser, error) {
+		if _, err := io.CopyN(io.Discard, r, 4); err != nil {
+			return nil, err
+		}
+		return io.NopCloser(r), nil
+	}
+	defer func() { ZstdDecoder = nil }()
+
+	if got := readEntry(t, func() (io.ReadCloser, error) { return Open(path) }); got != testContent {
+		t.Errorf("Expected %q, got %q", testContent, got)
+	}
+}
+
+func TestOpen_ZipReturnsErrArchive(t *testing.T) {
+	var buf bytes.Buffer
+	zip.NewWriter(&buf).Close()
+	path := writeFile(t, "lote.zip", buf.Bytes())
+
+	if _, err := Open(path); !errors.Is(err, ErrArchive) {
+		t.Errorf("Expected ErrArchive, got %v", err)
+	}
+}
+
+func TestEntries_SingleFile(t *testing.T) {
+	path := writeFile(t, "employees.csv.gz", gzipBytes(t, testContent))
+
+	entries, err := Entries(path)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(entries) != 1 || entries[0].Name != path || entries[0].Archive != "" {
+		t.Fatalf("Expected a single entry for %s, got %+v", path, entries)
+	}
+	if got := readEntry(t, entries[0].Open); got != testContent {
+		t.Errorf("Expected %q, got %q", testContent, got)
+	}
+}
+
+func TestEntries_Zip(t *testing.T) {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for _, item := range []struct {
+		name    string
+		content []byte
+	}{
+		{"rh.csv", []byte(testContent)},
+		{"dados/ti.csv.gz", gzipBytes(t, testContent)},
+		{"dados/", nil},
+		{"__MACOSX/dados/._ti.csv.gz", []byte("metadados")},
+		{"dados/.oculto", []byte("metadados")},
+	} {
+		w, err := archive.Create(item.name)
+		if err != nil {
+			t.Fatalf("Failed to create zip entry: %v", err)
+		}
+		w.Write(item.content)
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatalf("Failed to close zip: %v", err)
+	}
+	path := writeFile(t, "lote.zip", buf.Bytes())
+
+	entries, err := Entries(path)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %+v", entries)
+	}
+
+	for i, name := range []string{"rh.csv", "dados/ti.csv.gz"} {
+		if entries[i].Name != name || entries[i].Archive != path {
+			t.Errorf("Expected entry %s in %s, got %+v", name, path, entries[i])
+		}
+		// O item compactado com gzip também é descompactado
+		if got := readEntry(t, entries[i].Open); got != testContent {
+			t.Errorf("Expected %q for %s, got %q", testContent, name, got)
+		}
+	}
+}
+
+func TestEntries_FileNotFound(t *testing.T) {
+	if _, err := Entries(filepath.Join(t.TempDir(), "inexistente.csv")); err == nil || !strings.Contains(err.Error(), "erro ao abrir arquivo") {
+		t.Errorf("Expected open error, got %v", err)
+	}
+}
+
+func TestTrimCompressionExt(t *testing.T) {
+	tests := map[string]string{
+		"employees.csv.gz":  "employees.csv",
+		"export.JSONL.GZ":   "export.JSONL",
+		"export.json.zst":   "export.json",
+		"employees.csv":     "employees.csv",
+		"lote.zip":          "lote.zip",
+		"data/archive.gzip": "data/archive",
+	}
+
+	for name, expected := range tests {
+		if got := TrimCompressionExt(name); got != expected {
+			t.Errorf("TrimCompressionExt(%q) = %q, expected %q", name, got, expected)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package input
+
+import (
+	"io"
+
+	"github.com/seu-usuario/worker-pool-csv-processor/internal/models"
+)
+
+// MultiReader implementa models.RecordReader
+var _ models.RecordReader = (*MultiReader)(nil)
+
+// Source é uma das fontes de um MultiReader
+type Source struct {
+	// Name identifica a fonte nos erros (ex.: o nome do item no zip)
+	Name   string
+	Reader models.RecordReader
+}
+
+// MultiReader lê várias fontes em sequência, como se fossem uma só.
+// É usado para processar todos os arquivos de um zip.
+type MultiReader struct {
+	sources []Source
+}
+
+// NewMultiReader cria um leitor que percorre as fontes na ordem informada
+func NewMultiReader(sources ...Source) *MultiReader {
+	return &MultiReader{sources: sources}
+}
+
+// Open prepara a leitura. Cada fonte é aberta quando as anteriores terminam;
+// uma fonte que não abre, ou cuja leitura falha no meio, é reportada por Next
+// como *models.SourceError, e a leitura segue para a próxima. Os registros já
+// lidos de uma fonte que falhou no meio permanecem válidos.
+func (m *MultiReader) Open() (models.RecordStream, error) {
+	return &multiStream{sources: m.sources}, nil
+}
+
+// ReadAll lê todos os registros de todas as fontes. Fontes que não abrem ou
+// não podem ser lidas até o fim são reportadas junto com os registros inválidos.
+func (m *MultiReader) ReadAll() ([]*models.Record, []error, error) {
+	return models.ReadAll(m)
+}
+
+// multiStream mantém aberta apenas a fonte atual
+type multiStream struct {
+	sources []Source
+	current models.RecordStream
+	// name é o nome da fonte atual
+	name string
+	err  error
+}
+
+// advance abre a próxima fonte
+func (s *multiStream) advance() error {
+	source := s.sources[0]
+	s.sources = s.sources[1:]
+
+	stream, err := source.Reader.Open()
+	if err != nil {
+		return &models.SourceError{Source: source.Name, Err: err}
+	}
+	s.current = stream
+	s.name = source.Name
+	return nil
+}
+
+func (s *multiStream) Next() (*models.Record, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	for {
+		if s.current == nil {
+			if len(s.sources) == 0 {
+				s.err = io.EOF
+				return nil, io.EOF
+			}
+			if err := s.advance(); err != nil {
+				return nil, err
+			}
+		}
+
+		record, err := s.current.Next()
+		if err == nil || models.IsValidationError(err) || models.IsSourceError(err) {
+			return record, err
+		}
+		s.current.Close()
+		s.current = nil
+		// Uma falha de leitura encerra apenas a fonte atual
+		if err != io.EOF {
+			return nil, &models.SourceError{Source: s.name, Err: err}
+		}
+	}
+}
+
+func (s *multiStream) Close() error {
+	if s.current == nil {
+		return nil
+	}
+	return s.current.Close()
+}
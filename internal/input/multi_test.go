@@ -0,0 +1,159 @@
+package input
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/seu-usuario/worker-pool-csv-processor/internal/models"
+)
+
+// fakeReader devolve os resultados configurados, na ordem
+type fakeReader struct {
+	results []fakeResult
+	openErr error
+	closed  *int
+}
+
+type fakeResult struct {
+	record *models.Record
+	err    error
+}
+
+func (f *fakeReader) Open() (models.RecordStream, error) {
+	if f.openErr != nil {
+		return nil, f.openErr
+	}
+	return &fakeStream{results: f.results, closed: f.closed}, nil
+}
+
+func (f *fakeReader) ReadAll() ([]*models.Record, []error, error) {
+	return nil, nil, errors.New("não implementado")
+}
+
+type fakeStream struct {
+	results []fakeResult
+	closed  *int
+}
+
+func (s *fakeStream) Next() (*models.Record, error) {
+	if len(s.results) == 0 {
+		return nil, io.EOF
+	}
+	result := s.results[0]
+	s.results = s.results[1:]
+	return result.record, result.err
+}
+
+func (s *fakeStream) Close() error {
+	if s.closed != nil {
+		*s.closed++
+	}
+	return nil
+}
+
+func TestMultiReader_ReadsInOrder(t *testing.T) {
+	closed := 0
+	reader := NewMultiReader(
+		Source{Name: "a.csv", Reader: &fakeReader{closed: &closed, results: []fakeResult{
+			{record: &models.Record{Name: "a"}},
+			{err: &models.ValidationError{RowNumber: 2, Field: "age"}},
+		}}},
+		Source{Name: "vazio.csv", Reader: &fakeReader{closed: &closed}},
+		Source{Name: "b.csv", Reader: &fakeReader{closed: &closed, results: []fakeResult{
+			{record: &models.Record{Name: "b"}},
+		}}},
+	)
+
+	records, parseErrors, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(records) != 2 || records[0].Name != "a" || records[1].Name != "b" {
+		t.Errorf("Expected records a and b, got %+v", records)
+	}
+	if len(parseErrors) != 1 {
+		t.Errorf("Expected 1 parse error, got %v", parseErrors)
+	}
+	if closed != 3 {
+		t.Errorf("Expected 3 streams closed, got %d", closed)
+	}
+}
+
+func TestMultiReader_OpenErrorSkipsSource(t *testing.T) {
+	openErr := errors.New("colunas obrigatórias ausentes")
+	reader := NewMultiReader(
+		Source{Name: "a.csv", Reader: &fakeReader{results: []fakeResult{{record: &models.Record{Name: "a"}}}}},
+		Source{Name: "LEIAME.csv", Reader: &fakeReader{openErr: openErr}},
+		Source{Name: "b.csv", Reader: &fakeReader{results: []fakeResult{{record: &models.Record{Name: "b"}}}}},
+	)
+
+	stream, err := reader.Open()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer stream.Close()
+
+	if record, err := stream.Next(); err != nil || record.Name != "a" {
+		t.Fatalf("Expected record a, got %v, %v", record, err)
+	}
+
+	_, err = stream.Next()
+	var sourceErr *models.SourceError
+	if !errors.As(err, &sourceErr) || sourceErr.Source != "LEIAME.csv" || !errors.Is(err, openErr) {
+		t.Fatalf("Expected *models.SourceError for LEIAME.csv, got %v", err)
+	}
+
+	// A leitura continua na fonte seguinte
+	if record, err := stream.Next(); err != nil || record.Name != "b" {
+		t.Fatalf("Expected record b, got %v, %v", record, err)
+	}
+	if _, err := stream.Next(); err != io.EOF {
+		t.Errorf("Expected io.EOF, got %v", err)
+	}
+
+	records, parseErrors, err := reader.ReadAll()
+	if err != nil || len(records) != 2 || len(parseErrors) != 1 || !models.IsSourceError(parseErrors[0]) {
+		t.Errorf("Expected 2 records and 1 source error, got %d, %v, %v", len(records), parseErrors, err)
+	}
+}
+
+func TestMultiReader_ReadErrorSkipsRestOfSource(t *testing.T) {
+	readErr := errors.New("zip: checksum error")
+	closed := 0
+	reader := NewMultiReader(
+		Source{Name: "a.csv", Reader: &fakeReader{closed: &closed, results: []fakeResult{
+			{record: &models.Record{Name: "a"}},
+			{err: readErr},
+			{record: &models.Record{Name: "nunca lido"}},
+		}}},
+		Source{Name: "b.csv", Reader: &fakeReader{closed: &closed, results: []fakeResult{
+			{record: &models.Record{Name: "b"}},
+		}}},
+	)
+
+	records, parseErrors, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(records) != 2 || records[0].Name != "a" || records[1].Name != "b" {
+		t.Errorf("Expected records a and b, got %+v", records)
+	}
+	var sourceErr *models.SourceError
+	if len(parseErrors) != 1 || !errors.As(parseErrors[0], &sourceErr) || sourceErr.Source != "a.csv" || !errors.Is(sourceErr, readErr) {
+		t.Errorf("Expected a source error for a.csv, got %v", parseErrors)
+	}
+	if closed != 2 {
+		t.Errorf("Expected 2 streams closed, got %d", closed)
+	}
+}
+
+func TestMultiReader_Empty(t *testing.T) {
+	stream, err := NewMultiReader().Open()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := stream.Next(); err != io.EOF {
+		t.Errorf("Expected io.EOF, got %v", err)
+	}
+}
@@ -0,0 +1,324 @@
+package jsonreader
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/seu-usuario/worker-pool-csv-processor/internal/input"
+	"github.com/seu-usuario/worker-pool-csv-processor/internal/models"
+)
+
+// Format é o formato do arquivo JSON
+type Format string
+
+const (
+	// FormatAuto detecta o formato pelo primeiro caractere do arquivo
+	FormatAuto Format = ""
+	// FormatLines é JSON Lines (NDJSON): um objeto por linha
+	FormatLines Format = "jsonl"
+	// FormatArray é um array JSON de objetos
+	FormatArray Format = "json"
+)
+
+// maxLineSize é o tamanho máximo de uma linha em JSON Lines; linhas maiores
+// são reportadas como inválidas sem serem carregadas
+const maxLineSize = 1024 * 1024
+
+// dateLayouts são os formatos aceitos em created_at
+var dateLayouts = []string{"2006-01-02", time.RFC3339}
+
+// Reader implementa models.RecordReader
+var _ models.RecordReader = (*Reader)(nil)
+
+// Reader lê registros de arquivos JSON Lines ou de um array JSON.
+// Os objetos usam os mesmos nomes de campo do CSV (name, email, age, salary,
+// department, is_active, created_at); campos extras são ignorados.
+type Reader struct {
+	source string
+	open   func() (io.ReadCloser, error)
+	format Format
+}
+
+// NewReader cria um leitor que detecta o formato do arquivo
+func NewReader(filePath string) *Reader {
+	return NewReaderWithFormat(filePath, FormatAuto)
+}
+
+// NewReaderWithFormat cria um leitor para o formato informado.
+// Arquivos compactados com gzip (ou zstd, veja input.ZstdDecoder) são descompactados durante a leitura.
+func NewReaderWithFormat(filePath string, format Format) *Reader {
+	return NewReaderFromSource(filePath, func() (io.ReadCloser, error) {
+		return input.Open(filePath)
+	}, format)
+}
+
+// NewReaderFromSource cria um leitor para o conteúdo aberto por open, como um
+// item de um arquivo zip. source identifica a origem nos registros e erros.
+func NewReaderFromSource(source string, open func() (io.ReadCloser, error), format Format) *Reader {
+	return &Reader{
+		source: source,
+		open:   open,
+		format: format,
+	}
+}
+
+// ReadAll lê todo o arquivo e retorna os registros.
+// Para arquivos grandes, prefira Open, que lê um registro por vez.
+func (r *Reader) ReadAll() ([]*models.Record, []error, error) {
+	return models.ReadAll(r)
+}
+
+// Open abre o arquivo para leitura em streaming.
+// Em JSON Lines, RowNumber é a linha do arquivo; em um array, é a posição do
+// objeto no array, começando em 1.
+func (r *Reader) Open() (models.RecordStream, error) {
+	file, err := r.open()
+	if err != nil {
+		return nil, err
+	}
+
+	input := bufio.NewReader(file)
+	format := r.format
+	if format == FormatAuto {
+		format, err = detectFormat(input)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+	}
+
+	if format == FormatLines {
+		return &linesStream{file: file, source: r.source, input: input}, nil
+	}
+
+	decoder := json.NewDecoder(input)
+	decoder.UseNumber()
+	if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+		file.Close()
+		return nil, fmt.Errorf("erro ao ler JSON: o arquivo deve conter um array de objetos")
+	}
+	return &arrayStream{file: file, source: r.source, decoder: decoder}, nil
+}
+
+// detectFormat identifica um array JSON pelo '[' inicial; qualquer outro conteúdo é JSON Lines
+func detectFormat(input *bufio.Reader) (Format, error) {
+	for {
+		b, err := input.Peek(1)
+		if err == io.EOF {
+			return "", fmt.Errorf("arquivo JSON vazio")
+		}
+		if err != nil {
+			return "", fmt.Errorf("erro ao ler JSON: %w", err)
+		}
+
+		switch b[0] {
+		case ' ', '\t', '\r', '\n':
+			input.Discard(1)
+		case '[':
+			return FormatArray, nil
+		default:
+			return FormatLines, nil
+		}
+	}
+}
+
+// linesStream lê um objeto por linha, ignorando linhas em branco.
+// Uma linha com JSON inválido, ou maior que maxLineSize, é reportada e a
+// leitura continua na próxima.
+type linesStream struct {
+	file   io.ReadCloser
+	source string
+	input  *bufio.Reader
+	buf    []byte
+	line   int
+}
+
+func (s *linesStream) Next() (*models.Record, error) {
+	for {
+		raw, size, err := s.readLine()
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+		if err != nil {
+			return nil, fmt.Errorf("erro ao ler JSON Lines: %w", err)
+		}
+		s.line++
+
+		if size > maxLineSize {
+			return nil, &models.ValidationError{
+				RowNumber: s.line,
+				Field:     "json",
+				Code:      models.CodeInvalid,
+				Message:   fmt.Sprintf("linha maior que o limite de %d bytes", maxLineSize),
+				Value:     size,
+				Source:    s.source,
+			}
+		}
+
+		line := bytes.TrimSpace(raw)
+		if len(line) == 0 {
+			continue
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(line))
+		decoder.UseNumber()
+		var object map[string]interface{}
+		if err := decoder.Decode(&object); err != nil {
+			return nil, &models.ValidationError{
+				RowNumber: s.line,
+				Field:     "json",
+				Code:      models.CodeInvalid,
+				Message:   "JSON inválido",
+				Value:     err.Error(),
+				Source:    s.source,
+			}
+		}
+		record, err := parseObject(object, s.line)
+		return models.WithSource(record, err, s.source)
+	}
+}
+
+// readLine lê a próxima linha, sem o '\n', e retorna também o seu tamanho.
+// Quando a linha passa de maxLineSize, o restante é descartado até o fim da
+// linha e apenas o tamanho é útil. Retorna io.EOF quando não há mais linhas.
+func (s *linesStream) readLine() ([]byte, int, error) {
+	s.buf = s.buf[:0]
+	size := 0
+	for {
+		chunk, err := s.input.ReadSlice('\n')
+		size += len(chunk)
+		if size <= maxLineSize+1 {
+			s.buf = append(s.buf, chunk...)
+		}
+
+		switch {
+		case err == bufio.ErrBufferFull:
+			continue
+		case err == io.EOF && size == 0:
+			return nil, 0, io.EOF
+		case err != nil && err != io.EOF:
+			return nil, 0, err
+		}
+
+		if n := len(chunk); n > 0 && chunk[n-1] == '\n' {
+			size--
+			if size <= maxLineSize {
+				s.buf = s.buf[:len(s.buf)-1]
+			}
+		}
+		return s.buf, size, nil
+	}
+}
+
+func (s *linesStream) Close() error {
+	return s.file.Close()
+}
+
+// arrayStream lê os objetos de um array JSON um a um, sem carregar o array inteiro
+type arrayStream struct {
+	file     io.ReadCloser
+	source   string
+	decoder  *json.Decoder
+	position int
+	err      error
+}
+
+func (s *arrayStream) Next() (*models.Record, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	if !s.decoder.More() {
+		if token, err := s.decoder.Token(); err != nil || token != json.Delim(']') {
+			s.err = fmt.Errorf("erro ao ler JSON: array não terminado")
+			return nil, s.err
+		}
+		s.err = io.EOF
+		return nil, io.EOF
+	}
+
+	s.position++
+	var object map[string]interface{}
+	if err := s.decoder.Decode(&object); err != nil {
+		// Um elemento que não é objeto pode ser ignorado; erro de sintaxe encerra a leitura
+		if _, ok := err.(*json.UnmarshalTypeError); ok {
+			return nil, &models.ValidationError{
+				RowNumber: s.position,
+				Field:     "json",
+				Code:      models.CodeInvalid,
+				Message:   "elemento do array não é um objeto",
+				Value:     err.Error(),
+				Source:    s.source,
+			}
+		}
+		s.err = fmt.Errorf("erro ao ler JSON: %w", err)
+		return nil, s.err
+	}
+	record, err := parseObject(object, s.position)
+	return models.WithSource(record, err, s.source)
+}
+
+func (s *arrayStream) Close() error {
+	return s.file.Close()
+}
+
+// parseObject converte um objeto JSON em um Record (veja models.ParseRecord)
+func parseObject(object map[string]interface{}, rowNumber int) (*models.Record, error) {
+	return models.ParseRecord(objectFields(object), rowNumber, "YYYY-MM-DD, RFC 3339")
+}
+
+// objectFields implementa models.Fields sobre um objeto JSON, aceitando
+// strings, números e booleanos em qualquer campo
+type objectFields map[string]interface{}
+
+// Text retorna o valor do campo como texto. Campos ausentes, nulos ou de
+// outros tipos retornam "".
+func (f objectFields) Text(field string) string {
+	switch v := f[field].(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return ""
+	}
+}
+
+func (f objectFields) Int(field string) (int, bool) {
+	value, err := strconv.Atoi(f.Text(field))
+	return value, err == nil
+}
+
+func (f objectFields) Float(field string) (float64, bool) {
+	value, err := strconv.ParseFloat(f.Text(field), 64)
+	return value, err == nil
+}
+
+func (f objectFields) Bool(field string) (bool, bool) {
+	value, err := strconv.ParseBool(f.Text(field))
+	return value, err == nil
+}
+
+func (f objectFields) Date(field string) (time.Time, bool) {
+	value, err := parseDate(f.Text(field))
+	return value, err == nil
+}
+
+// parseDate tenta cada formato de data aceito, na ordem
+func parseDate(value string) (time.Time, error) {
+	var err error
+	for _, layout := range dateLayouts {
+		var date time.Time
+		if date, err = time.Parse(layout, value); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, err
+}
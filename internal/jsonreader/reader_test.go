@@ -0,0 +1,251 @@
+package jsonreader
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/seu-usuario/worker-pool-csv-processor/internal/models"
+)
+
+func createTempFile(content string) (string, error) {
+	tmpfile, err := os.CreateTemp("", "test_*.json")
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := tmpfile.Write([]byte(content)); err != nil {
+		tmpfile.Close()
+		os.Remove(tmpfile.Name())
+		return "", err
+	}
+
+	if err := tmpfile.Close(); err != nil {
+		os.Remove(tmpfile.Name())
+		return "", err
+	}
+
+	return tmpfile.Name(), nil
+}
+
+func TestReadAll_JSONLines(t *testing.T) {
+	content := `{"name":"João Silva","email":"joao@empresa.com","age":28,"salary":5500.5,"department":"TI","is_active":true,"created_at":"2024-01-15"}
+
+{"name":"Maria Santos","email":"maria@empresa.com","age":"32","salary":"6200","department":"RH","is_active":"false","created_at":"2024-01-16T10:00:00Z","extra":1}
+`
+
+	filePath, err := createTempFile(content)
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(filePath)
+
+	records, parseErrors, err := NewReader(filePath).ReadAll()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(parseErrors) != 0 {
+		t.Fatalf("Expected no parse errors, got %v", parseErrors)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(records))
+	}
+
+	rec := records[0]
+	if rec.Name != "João Silva" || rec.Age != 28 || rec.Salary != 5500.5 || !rec.IsActive || rec.RowNumber != 1 {
+		t.Errorf("Unexpected first record: %+v", rec)
+	}
+	if expected := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC); !rec.CreatedAt.Equal(expected) {
+		t.Errorf("Expected date %v, got %v", expected, rec.CreatedAt)
+	}
+
+	// Valores como string também são aceitos; a linha em branco conta na numeração
+	rec = records[1]
+	if rec.Age != 32 || rec.Salary != 6200 || rec.IsActive || rec.RowNumber != 3 {
+		t.Errorf("Unexpected second record: %+v", rec)
+	}
+}
+
+func TestOpen_JSONLinesInvalidLineContinues(t *testing.T) {
+	content := `{"name":"João Silva","email":"joao@empresa.com","age":28,"salary":5500,"department":"TI","is_active":true,"created_at":"2024-01-15"}
+{"name": "quebrado"
+{"name":"Maria Santos","email":"maria@empresa.com","age":200,"salary":6200,"department":"RH","is_active":true,"created_at":"2024-01-16"}
+{"name":"Pedro","email":"pedro@empresa.com","age":45,"salary":8500,"department":"TI","is_active":true,"created_at":"2024-01-17"}
+`
+
+	filePath, err := createTempFile(content)
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(filePath)
+
+	records, parseErrors, err := NewReaderWithFormat(filePath, FormatLines).ReadAll()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(records) != 2 {
+		t.Errorf("Expected 2 records, got %d", len(records))
+	}
+	if len(parseErrors) != 2 {
+		t.Fatalf("Expected 2 parse errors, got %d", len(parseErrors))
+	}
+
+	expected := []struct {
+		row   int
+		field string
+	}{{2, "json"}, {3, "age"}}
+	for i, e := range expected {
+		var validationErr *models.ValidationError
+		if !errors.As(parseErrors[i], &validationErr) {
+			t.Fatalf("Expected *models.ValidationError, got %T", parseErrors[i])
+		}
+		if validationErr.RowNumber != e.row || validationErr.Field != e.field {
+			t.Errorf("Expected error on line %d field %q, got line %d field %q",
+				e.row, e.field, validationErr.RowNumber, validationErr.Field)
+		}
+	}
+}
+
+func TestOpen_JSONLinesLongLineContinues(t *testing.T) {
+	valid := `{"name":"Pedro","email":"pedro@empresa.com","age":45,"salary":8500,"department":"TI","is_active":true,"created_at":"2024-01-17"}`
+	long := `{"name":"` + strings.Repeat("a", maxLineSize) + `"}`
+	content := valid + "\n" + long + "\n" + valid + "\n"
+
+	filePath, err := createTempFile(content)
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(filePath)
+
+	records, parseErrors, err := NewReaderWithFormat(filePath, FormatLines).ReadAll()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(records))
+	}
+	if records[0].RowNumber != 1 || records[1].RowNumber != 3 {
+		t.Errorf("Expected records on lines 1 and 3, got %d and %d", records[0].RowNumber, records[1].RowNumber)
+	}
+	if len(parseErrors) != 1 {
+		t.Fatalf("Expected 1 parse error, got %d", len(parseErrors))
+	}
+
+	var validationErr *models.ValidationError
+	if !errors.As(parseErrors[0], &validationErr) {
+		t.Fatalf("Expected *models.ValidationError, got %T", parseErrors[0])
+	}
+	if validationErr.RowNumber != 2 || validationErr.Field != "json" || validationErr.Value != len(long) {
+		t.Errorf("Unexpected error for long line: %+v", validationErr)
+	}
+}
+
+func TestReadAll_AllFieldErrorsInObject(t *testing.T) {
+	content := `{"name":"","email":"joao@empresa.com","age":28,"salary":5500,"department":"TI","is_active":"talvez","created_at":"2024-01-15"}`
+
+	filePath, err := createTempFile(content)
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(filePath)
+
+	_, parseErrors, err := NewReader(filePath).ReadAll()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(parseErrors) != 1 {
+		t.Fatalf("Expected 1 parse error, got %d", len(parseErrors))
+	}
+
+	validationErrs := models.ValidationErrorsOf(parseErrors[0])
+	if len(validationErrs) != 2 || validationErrs[0].Field != "name" || validationErrs[1].Field != "is_active" {
+		t.Fatalf("Expected errors on name and is_active, got %v", validationErrs)
+	}
+	if !errors.Is(parseErrors[0], models.ErrRequired) || validationErrs[1].Code != models.CodeInvalid {
+		t.Errorf("Expected required and invalid codes, got %q and %q", validationErrs[0].Code, validationErrs[1].Code)
+	}
+}
+
+func TestReadAll_JSONArray(t *testing.T) {
+	content := `
+[
+  {"name":"João Silva","email":"joao@empresa.com","age":28,"salary":5500,"department":"TI","is_active":true,"created_at":"2024-01-15"},
+  42,
+  {"name":"","email":"maria@empresa.com","age":32,"salary":6200,"department":"RH","is_active":true,"created_at":"2024-01-16"},
+  {"name":"Pedro","email":"pedro@empresa.com","age":45,"salary":8500,"department":"TI","is_active":true,"created_at":"2024-01-17"}
+]`
+
+	filePath, err := createTempFile(content)
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(filePath)
+
+	records, parseErrors, err := NewReader(filePath).ReadAll()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(records) != 2 || records[1].RowNumber != 4 {
+		t.Errorf("Expected records at positions 1 and 4, got %d records", len(records))
+	}
+	if len(parseErrors) != 2 {
+		t.Errorf("Expected 2 parse errors, got %v", parseErrors)
+	}
+}
+
+func TestOpen_JSONArraySyntaxErrorIsFatal(t *testing.T) {
+	content := `[{"name":"João Silva","email":"joao@empresa.com","age":28,"salary":5500,"department":"TI","is_active":true,"created_at":"2024-01-15"}, {"name": }]`
+
+	filePath, err := createTempFile(content)
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(filePath)
+
+	stream, err := NewReaderWithFormat(filePath, FormatArray).Open()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer stream.Close()
+
+	if _, err := stream.Next(); err != nil {
+		t.Fatalf("Expected first record, got %v", err)
+	}
+	_, err = stream.Next()
+	if err == nil || err == io.EOF || models.IsValidationError(err) {
+		t.Errorf("Expected fatal error, got %v", err)
+	}
+}
+
+func TestOpen_NotAnArray(t *testing.T) {
+	filePath, err := createTempFile(`{"name":"João"}`)
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(filePath)
+
+	if _, err := NewReaderWithFormat(filePath, FormatArray).Open(); err == nil {
+		t.Error("Expected error for object at top level, got nil")
+	}
+}
+
+func TestOpen_EmptyFile(t *testing.T) {
+	filePath, err := createTempFile("  \n")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(filePath)
+
+	if _, err := NewReader(filePath).Open(); err == nil {
+		t.Error("Expected error for empty file, got nil")
+	}
+}
+
+func TestOpen_FileNotFound(t *testing.T) {
+	if _, err := NewReader("nonexistent.json").Open(); err == nil {
+		t.Error("Expected error for nonexistent file, got nil")
+	}
+}
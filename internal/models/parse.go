@@ -0,0 +1,125 @@
+package models
+
+import (
+	"io"
+	"strings"
+	"time"
+)
+
+// Fields dá acesso aos campos de uma linha lida por um RecordReader. Cada
+// leitor converte os valores no seu formato (texto do CSV, tipos do JSON,
+// células da planilha); as regras de cada campo ficam em ParseRecord.
+// Os métodos tipados retornam false quando o valor não pode ser convertido.
+type Fields interface {
+	// Text retorna o valor como texto; é também o Value dos erros
+	Text(field string) string
+	Int(field string) (int, bool)
+	Float(field string) (float64, bool)
+	Bool(field string) (bool, bool)
+	Date(field string) (time.Time, bool)
+}
+
+// ParseRecord converte os campos de uma linha em um Record, com as mesmas
+// regras para todos os leitores. Todos os campos são verificados, para que a
+// linha seja corrigida de uma vez, e os inválidos são retornados juntos em uma
+// ValidationErrors. dateFormats descreve os formatos aceitos em created_at,
+// para a mensagem de erro.
+func ParseRecord(fields Fields, rowNumber int, dateFormats string) (*Record, error) {
+	var errs ValidationErrors
+	invalid := func(field, code, message string) {
+		errs = append(errs, &ValidationError{
+			RowNumber: rowNumber,
+			Field:     field,
+			Code:      code,
+			Message:   message,
+			Value:     fields.Text(field),
+		})
+	}
+
+	// Nome
+	name := fields.Text("name")
+	if name == "" {
+		invalid("name", CodeRequired, "nome não pode ser vazio")
+	}
+
+	// Email
+	email := fields.Text("email")
+	if email == "" {
+		invalid("email", CodeRequired, "email não pode ser vazio")
+	}
+
+	// Age
+	age, ok := fields.Int("age")
+	if !ok || age < 0 || age > 150 {
+		invalid("age", CodeInvalid, "idade inválida (deve ser entre 0 e 150)")
+	}
+
+	// Salary
+	salary, ok := fields.Float("salary")
+	if !ok || salary < 0 {
+		invalid("salary", CodeInvalid, "salário inválido (deve ser um número positivo)")
+	}
+
+	// Department, sem espaços nas pontas: é comparado com o cadastro pelo nome
+	department := strings.TrimSpace(fields.Text("department"))
+	if department == "" {
+		invalid("department", CodeRequired, "departamento não pode ser vazio")
+	}
+
+	// IsActive
+	isActive, ok := fields.Bool("is_active")
+	if !ok {
+		invalid("is_active", CodeInvalid, "valor inválido (deve ser true ou false)")
+	}
+
+	// CreatedAt
+	createdAt, ok := fields.Date("created_at")
+	if !ok {
+		invalid("created_at", CodeInvalid, "data inválida (formatos aceitos: "+dateFormats+")")
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	return &Record{
+		Name:        name,
+		Email:       email,
+		Age:         age,
+		Salary:      salary,
+		Department:  department,
+		IsActive:    isActive,
+		CreatedAt:   createdAt,
+		ProcessedAt: time.Now(),
+		RowNumber:   rowNumber,
+	}, nil
+}
+
+// ReadAll lê todos os registros de reader, para implementações de
+// RecordReader.ReadAll. Registros inválidos e fontes ignoradas são retornados
+// em parseErrors e a leitura continua; qualquer outro erro a encerra.
+func ReadAll(reader RecordReader) ([]*Record, []error, error) {
+	stream, err := reader.Open()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer stream.Close()
+
+	var records []*Record
+	var parseErrors []error
+
+	for {
+		record, err := stream.Next()
+		if err == io.EOF {
+			return records, parseErrors, nil
+		}
+		if IsValidationError(err) || IsSourceError(err) {
+			parseErrors = append(parseErrors, err)
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		records = append(records, record)
+	}
+}
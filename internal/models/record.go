@@ -1,34 +1,222 @@
 package models
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 )
 
 // Record representa um registro do CSV após validação
 type Record struct {
-	ID          int       `json:"id"`
-	Name        string    `json:"name"`
-	Email       string    `json:"email"`
-	Age         int       `json:"age"`
-	Salary      float64   `json:"salary"`
-	Department  string    `json:"department"`
-	IsActive    bool      `json:"is_active"`
-	CreatedAt   time.Time `json:"created_at"`
-	ProcessedAt time.Time `json:"processed_at"`
-	RowNumber   int       `json:"row_number"` // Linha original do CSV
+	ID           int       `json:"id"`
+	Name         string    `json:"name"`
+	Email        string    `json:"email"`
+	Age          int       `json:"age"`
+	Salary       float64   `json:"salary"`
+	Department   string    `json:"department"`
+	DepartmentID int       `json:"department_id,omitempty"` // Chave do departamento no banco (preenchida ao ler do banco)
+	IsActive     bool      `json:"is_active"`
+	CreatedAt    time.Time `json:"created_at"`
+	ProcessedAt  time.Time `json:"processed_at"`
+	RowNumber    int       `json:"row_number"`       // Linha original do CSV
+	Source       string    `json:"source,omitempty"` // Arquivo de origem (em um zip, o nome do item)
 }
 
+// DefaultDepartments são os departamentos cadastrados em um banco novo e
+// aceitos pelo validador padrão
+var DefaultDepartments = []string{"TI", "RH", "Financeiro", "Vendas", "Marketing", "Operações", "Jurídico", "Administração"}
+
+// Códigos das regras de validação (ValidationError.Code)
+const (
+	// CodeRequired indica um campo obrigatório vazio
+	CodeRequired = "required"
+	// CodeInvalid indica um valor que não pôde ser interpretado (número, data, JSON...)
+	CodeInvalid = "invalid"
+	// CodeRange indica um número fora do intervalo permitido
+	CodeRange = "range"
+	// CodeLength indica um texto curto ou longo demais
+	CodeLength = "length"
+	// CodePattern indica um valor que não segue o formato exigido
+	CodePattern = "pattern"
+	// CodeEnum indica um valor fora da lista de valores aceitos
+	CodeEnum = "enum"
+	// CodeDisposable indica um email de um provedor de email temporário
+	CodeDisposable = "disposable"
+	// CodeTypo indica um provável erro de digitação no domínio do email (veja ValidationError.Suggestion)
+	CodeTypo = "typo"
+	// CodeNoMX indica um domínio de email que não recebe mensagens (sem registros MX nem endereço)
+	CodeNoMX = "mx"
+)
+
+// Erros para uso com errors.Is, um por código de regra.
+// Para filtrar também pelo campo, use &ValidationError{Field: "email", Code: CodePattern}.
+var (
+	ErrRequired   = &ValidationError{Code: CodeRequired}
+	ErrInvalid    = &ValidationError{Code: CodeInvalid}
+	ErrRange      = &ValidationError{Code: CodeRange}
+	ErrLength     = &ValidationError{Code: CodeLength}
+	ErrPattern    = &ValidationError{Code: CodePattern}
+	ErrEnum       = &ValidationError{Code: CodeEnum}
+	ErrDisposable = &ValidationError{Code: CodeDisposable}
+	ErrTypo       = &ValidationError{Code: CodeTypo}
+	ErrNoMX       = &ValidationError{Code: CodeNoMX}
+)
+
 // ValidationError representa um erro de validação
 type ValidationError struct {
 	RowNumber int
 	Field     string
-	Message   string
-	Value     interface{}
+	// Code identifica a regra violada (veja as constantes Code*)
+	Code    string
+	Message string
+	Value   interface{}
+	// Suggestion é o valor provavelmente pretendido (ex.: o email com o domínio corrigido)
+	Suggestion string
+	// Source é o arquivo de origem do registro (em um zip, o nome do item)
+	Source string
+	// Sheet e Column localizam a célula em planilhas (vazios nos demais formatos)
+	Sheet  string
+	Column string
 }
 
 func (e *ValidationError) Error() string {
-	return fmt.Sprintf("Linha %d, Campo '%s': %s (Valor: %v)", e.RowNumber, e.Field, e.Message, e.Value)
+	location := fmt.Sprintf("Linha %d", e.RowNumber)
+	if e.Sheet != "" {
+		location = fmt.Sprintf("Planilha '%s', %s", e.Sheet, location)
+	}
+	if e.Source != "" {
+		location = fmt.Sprintf("Arquivo '%s', %s", e.Source, location)
+	}
+	if e.Column != "" {
+		location += ", Coluna " + e.Column
+	}
+	return fmt.Sprintf("%s, Campo '%s': %s (Valor: %v)", location, e.Field, e.Message, e.Value)
+}
+
+// Is compara com um *ValidationError usado como filtro: Field e Code vazios
+// no alvo aceitam qualquer valor. Assim, errors.Is(err, ErrRequired) ou
+// errors.Is(err, &ValidationError{Field: "email"}) funcionam também sobre ValidationErrors.
+func (e *ValidationError) Is(target error) bool {
+	t, ok := target.(*ValidationError)
+	if !ok {
+		return false
+	}
+	return (t.Field == "" || t.Field == e.Field) && (t.Code == "" || t.Code == e.Code)
+}
+
+// ValidationErrors reúne os erros de validação de um mesmo registro, um por
+// regra violada, cada um com o campo, o código da regra, o valor e a linha
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap expõe cada erro a errors.As e errors.Is
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// Fields retorna os campos com erro, sem repetição, na ordem em que aparecem
+func (e ValidationErrors) Fields() []string {
+	var fields []string
+	seen := make(map[string]bool, len(e))
+	for _, err := range e {
+		if !seen[err.Field] {
+			seen[err.Field] = true
+			fields = append(fields, err.Field)
+		}
+	}
+	return fields
+}
+
+// Err retorna nil se a lista estiver vazia, ou a própria lista
+func (e ValidationErrors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// ValidationErrorsOf retorna os erros de validação contidos em err: todos os
+// de uma ValidationErrors ou o único *ValidationError. Para outros erros, retorna nil.
+func ValidationErrorsOf(err error) ValidationErrors {
+	var validationErrs ValidationErrors
+	if errors.As(err, &validationErrs) {
+		return validationErrs
+	}
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return ValidationErrors{validationErr}
+	}
+	return nil
+}
+
+// WithSource registra a origem no registro ou nos erros de validação retornados por
+// um leitor, repassando o par sem alteração nos demais casos
+func WithSource(record *Record, err error, source string) (*Record, error) {
+	if record != nil {
+		record.Source = source
+	}
+	for _, validationErr := range ValidationErrorsOf(err) {
+		validationErr.Source = source
+	}
+	return record, err
+}
+
+// IsValidationError indica se o erro se refere a um único registro inválido
+// (contém um *ValidationError ou uma ValidationErrors), e não a uma falha de leitura da fonte
+func IsValidationError(err error) bool {
+	var validationErr *ValidationError
+	return errors.As(err, &validationErr)
+}
+
+// SourceError indica que uma das fontes de um stream com várias fontes (como
+// os arquivos de um zip) não pôde ser aberta. A leitura continua na próxima fonte.
+type SourceError struct {
+	Source string
+	Err    error
+}
+
+func (e *SourceError) Error() string {
+	return fmt.Sprintf("Arquivo '%s' ignorado: %v", e.Source, e.Err)
+}
+
+func (e *SourceError) Unwrap() error {
+	return e.Err
+}
+
+// IsSourceError indica se o erro se refere a uma fonte ignorada, e não a uma
+// falha que encerra a leitura
+func IsSourceError(err error) bool {
+	var sourceErr *SourceError
+	return errors.As(err, &sourceErr)
+}
+
+// RecordStream entrega os registros de uma fonte um a um.
+// Next retorna io.EOF ao fim da fonte, um *ValidationError para um registro
+// inválido ou um *SourceError para uma fonte ignorada; após esses dois a
+// leitura pode continuar. Qualquer outro erro é definitivo.
+type RecordStream interface {
+	Next() (*Record, error)
+	Close() error
+}
+
+// RecordReader é uma fonte de registros, como um arquivo CSV ou JSON
+type RecordReader interface {
+	// Open abre a fonte para leitura em streaming
+	Open() (RecordStream, error)
+	// ReadAll lê todos os registros, separando os registros inválidos
+	ReadAll() ([]*Record, []error, error)
 }
 
 // GetName retorna o nome do registro (para logs)
@@ -42,5 +230,7 @@ type ProcessingResult struct {
 	Record    *Record
 	Success   bool
 	Error     error
-	Duration  time.Duration
+	// Warnings são os avisos da validação, que não impedem a importação
+	Warnings ValidationErrors
+	Duration time.Duration
 }
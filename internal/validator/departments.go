@@ -0,0 +1,87 @@
+package validator
+
+import (
+	"sync"
+	"time"
+)
+
+// DepartmentLookup consulta o cadastro de departamentos.
+// *database.DB implementa esta interface.
+type DepartmentLookup interface {
+	// IsActiveDepartment indica se o departamento está cadastrado e ativo
+	IsActiveDepartment(name string) (bool, error)
+}
+
+// StaticDepartments é um cadastro fixo em memória, com todos os departamentos ativos
+type StaticDepartments map[string]bool
+
+// NewStaticDepartments cria um cadastro fixo com os nomes informados
+func NewStaticDepartments(names ...string) StaticDepartments {
+	departments := make(StaticDepartments, len(names))
+	for _, name := range names {
+		departments[name] = true
+	}
+	return departments
+}
+
+// IsActiveDepartment implementa DepartmentLookup
+func (s StaticDepartments) IsActiveDepartment(name string) (bool, error) {
+	return s[name], nil
+}
+
+// DepartmentCache guarda as respostas de outro DepartmentLookup por um tempo,
+// para que cada registro não gere uma consulta ao banco. É seguro para uso concorrente.
+type DepartmentCache struct {
+	lookup DepartmentLookup
+	ttl    time.Duration
+	now    func() time.Time
+
+	mu      sync.RWMutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	active    bool
+	expiresAt time.Time
+}
+
+// NewDepartmentCache cria um cache sobre lookup. Cada resposta, positiva ou
+// negativa, vale por ttl; assim, departamentos cadastrados ou desativados
+// durante uma importação passam a valer em até ttl.
+func NewDepartmentCache(lookup DepartmentLookup, ttl time.Duration) *DepartmentCache {
+	return &DepartmentCache{
+		lookup:  lookup,
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]cacheEntry),
+	}
+}
+
+// IsActiveDepartment implementa DepartmentLookup. Erros da consulta não são guardados.
+func (c *DepartmentCache) IsActiveDepartment(name string) (bool, error) {
+	now := c.now()
+
+	c.mu.RLock()
+	entry, ok := c.entries[name]
+	c.mu.RUnlock()
+	if ok && now.Before(entry.expiresAt) {
+		return entry.active, nil
+	}
+
+	active, err := c.lookup.IsActiveDepartment(name)
+	if err != nil {
+		return false, err
+	}
+
+	c.mu.Lock()
+	c.entries[name] = cacheEntry{active: active, expiresAt: now.Add(c.ttl)}
+	c.mu.Unlock()
+	return active, nil
+}
+
+// Invalidate descarta todas as respostas guardadas
+func (c *DepartmentCache) Invalidate() {
+	c.mu.Lock()
+	c.entries = make(map[string]cacheEntry)
+	c.mu.Unlock()
+}
@@ -0,0 +1,121 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/seu-usuario/worker-pool-csv-processor/internal/models"
+)
+
+// countingLookup conta as consultas e pode falhar
+type countingLookup struct {
+	departments StaticDepartments
+	calls       int
+	err         error
+}
+
+func (l *countingLookup) IsActiveDepartment(name string) (bool, error) {
+	l.calls++
+	if l.err != nil {
+		return false, l.err
+	}
+	return l.departments[name], nil
+}
+
+func TestDepartmentCache(t *testing.T) {
+	lookup := &countingLookup{departments: NewStaticDepartments("TI")}
+	cache := NewDepartmentCache(lookup, time.Minute)
+	now := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		if active, _ := cache.IsActiveDepartment("TI"); !active {
+			t.Error("Expected TI to be active")
+		}
+		if active, _ := cache.IsActiveDepartment("Compras"); active {
+			t.Error("Expected Compras to be inactive")
+		}
+	}
+	if lookup.calls != 2 {
+		t.Errorf("Expected 2 lookups (positive and negative cached), got %d", lookup.calls)
+	}
+
+	// Após o ttl, a consulta é refeita e vê a mudança no cadastro
+	lookup.departments["Compras"] = true
+	now = now.Add(time.Minute)
+	if active, _ := cache.IsActiveDepartment("Compras"); !active {
+		t.Error("Expected Compras to be active after ttl")
+	}
+
+	cache.Invalidate()
+	cache.IsActiveDepartment("TI")
+	if lookup.calls != 4 {
+		t.Errorf("Expected 4 lookups, got %d", lookup.calls)
+	}
+}
+
+func TestDepartmentCache_ErrorsNotCached(t *testing.T) {
+	lookup := &countingLookup{err: errors.New("banco indisponível")}
+	cache := NewDepartmentCache(lookup, time.Minute)
+
+	if _, err := cache.IsActiveDepartment("TI"); err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+
+	lookup.err = nil
+	lookup.departments = NewStaticDepartments("TI")
+	if active, err := cache.IsActiveDepartment("TI"); err != nil || !active {
+		t.Errorf("Expected retry after error, got active=%v err=%v", active, err)
+	}
+}
+
+func TestValidate_DepartmentLookup(t *testing.T) {
+	lookup := &countingLookup{departments: NewStaticDepartments("Logística")}
+	v, err := NewValidatorWithOptions(Options{Departments: lookup})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	record := validRecord()
+	record.Department = "Logística"
+	if err := v.Validate(record); err != nil {
+		t.Errorf("Expected no error for registered department, got %v", err)
+	}
+
+	record.Department = "TI"
+	err = v.Validate(record)
+	if !errors.Is(err, &models.ValidationError{Field: "department", Code: models.CodeEnum}) {
+		t.Errorf("Expected department enum error, got %v", err)
+	}
+
+	lookup.err = errors.New("banco indisponível")
+	if err := v.Validate(record); !errors.Is(err, lookup.err) || models.IsValidationError(err) {
+		t.Errorf("Expected lookup error, got %v", err)
+	}
+}
+
+func TestValidate_DefaultDepartments(t *testing.T) {
+	// Sem cadastro, valem os departamentos padrão, como em NewValidator
+	withOptions, err := NewValidatorWithOptions(Options{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	withRules, err := NewValidatorWithRules(DefaultRules())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for _, v := range []*Validator{NewValidator(), withOptions, withRules} {
+		record := validRecord()
+		record.Department = "RH"
+		if err := v.Validate(record); err != nil {
+			t.Errorf("Expected default department to be accepted, got %v", err)
+		}
+
+		record.Department = "Qualquer"
+		if err := v.Validate(record); !errors.Is(err, &models.ValidationError{Field: "department", Code: models.CodeEnum}) {
+			t.Errorf("Expected department enum error, got %v", err)
+		}
+	}
+}
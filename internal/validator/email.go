@@ -0,0 +1,332 @@
+package validator
+
+import (
+	"bufio"
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/seu-usuario/worker-pool-csv-processor/internal/models"
+)
+
+// defaultLookupTimeout limita cada consulta ao DNS quando EmailOptions.LookupTimeout é zero
+const defaultLookupTimeout = 5 * time.Second
+
+//go:embed disposable_domains.txt
+var disposableDomainsFile string
+
+// commonProviders são os provedores de email comparados com o domínio de cada
+// email para sugerir correções de digitação (ex.: "gmial.com" → "gmail.com")
+var commonProviders = []string{
+	"gmail.com", "googlemail.com",
+	"hotmail.com", "hotmail.com.br", "outlook.com", "outlook.com.br", "live.com", "msn.com",
+	"yahoo.com", "yahoo.com.br", "ymail.com",
+	"icloud.com", "me.com", "aol.com", "protonmail.com", "proton.me",
+	"mail.com", "email.com", "gmx.com",
+	"uol.com.br", "bol.com.br", "terra.com.br", "ig.com.br", "globo.com", "globomail.com",
+}
+
+// Resolver consulta o DNS na verificação de MX. *net.Resolver (como
+// net.DefaultResolver) implementa esta interface; nos testes, use um resolver falso.
+type Resolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// EmailOptions habilita a validação estendida de email (veja Options.Email):
+// o domínio é normalizado para ASCII (IDN em Punycode, minúsculas), domínios
+// de email temporário são rejeitados e prováveis erros de digitação de
+// provedores comuns geram um aviso com a correção em ValidationError.Suggestion.
+type EmailOptions struct {
+	// DisposableDomains são domínios temporários além da lista embutida
+	DisposableDomains []string
+	// RejectTypos trata um provável erro de digitação como erro, e não como aviso
+	RejectTypos bool
+	// Resolver habilita a verificação de MX: o domínio precisa ter registros MX
+	// ou, na falta deles, um endereço (nil = não consulta o DNS)
+	Resolver Resolver
+	// LookupTimeout limita cada consulta ao DNS (padrão: 5s)
+	LookupTimeout time.Duration
+}
+
+// emailChecker aplica as verificações de EmailOptions. É seguro para uso concorrente.
+type emailChecker struct {
+	disposable  map[string]bool
+	rejectTypos bool
+	resolver    Resolver
+	timeout     time.Duration
+
+	// mx guarda, por domínio, se ele recebe emails: uma importação costuma
+	// repetir poucos domínios
+	mu sync.Mutex
+	mx map[string]bool
+}
+
+func newEmailChecker(opts EmailOptions) (*emailChecker, error) {
+	c := &emailChecker{
+		disposable:  make(map[string]bool),
+		rejectTypos: opts.RejectTypos,
+		resolver:    opts.Resolver,
+		timeout:     opts.LookupTimeout,
+		mx:          make(map[string]bool),
+	}
+	if c.timeout <= 0 {
+		c.timeout = defaultLookupTimeout
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(disposableDomainsFile))
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" && !strings.HasPrefix(line, "#") {
+			c.disposable[line] = true
+		}
+	}
+	for _, domain := range opts.DisposableDomains {
+		ascii, err := ToASCII(strings.TrimSpace(domain))
+		if err != nil {
+			return nil, fmt.Errorf("domínio temporário inválido %q: %w", domain, err)
+		}
+		c.disposable[ascii] = true
+	}
+	return c, nil
+}
+
+// NormalizeEmail remove espaços nas extremidades e converte o domínio do email
+// para ASCII com ToASCII (ex.: "Ana@Exemplo.COM.br" → "Ana@exemplo.com.br").
+// A parte local não é alterada, pois pode diferenciar maiúsculas.
+func NormalizeEmail(email string) (string, error) {
+	email = strings.TrimSpace(email)
+	at := strings.LastIndex(email, "@")
+	if at <= 0 || at == len(email)-1 {
+		return "", fmt.Errorf("email sem usuário ou domínio: %q", email)
+	}
+
+	domain, err := ToASCII(email[at+1:])
+	if err != nil {
+		return "", err
+	}
+	return email[:at] + "@" + domain, nil
+}
+
+// check retorna as verificações estendidas violadas pelo email. Um domínio
+// temporário dispensa as demais verificações; um provável erro de digitação
+// não, pois pode ser apenas um aviso. Retorna erro apenas se ctx terminar
+// durante a consulta ao DNS.
+func (c *emailChecker) check(ctx context.Context, email string) ([]violation, error) {
+	normalized, err := NormalizeEmail(email)
+	if err != nil {
+		return []violation{{code: models.CodeInvalid, message: "email inválido: " + err.Error()}}, nil
+	}
+	at := strings.LastIndex(normalized, "@")
+	local, domain := normalized[:at], normalized[at+1:]
+
+	if c.isDisposable(domain) {
+		return []violation{{code: models.CodeDisposable, message: "domínio de email temporário não é aceito"}}, nil
+	}
+
+	var violations []violation
+	if provider := suggestProvider(domain); provider != "" {
+		violations = append(violations, violation{
+			code:       models.CodeTypo,
+			message:    fmt.Sprintf("domínio provavelmente digitado errado (quis dizer %s?)", provider),
+			suggestion: local + "@" + provider,
+			warning:    !c.rejectTypos,
+		})
+	}
+
+	if c.resolver != nil {
+		ok, err := c.receivesMail(ctx, domain)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			violations = append(violations, violation{code: models.CodeNoMX, message: "domínio não recebe emails (sem registros MX)"})
+		}
+	}
+	return violations, nil
+}
+
+// isDisposable indica se o domínio, ou um domínio acima dele, é temporário
+func (c *emailChecker) isDisposable(domain string) bool {
+	for {
+		if c.disposable[domain] {
+			return true
+		}
+		dot := strings.IndexByte(domain, '.')
+		if dot < 0 {
+			return false
+		}
+		domain = domain[dot+1:]
+	}
+}
+
+// suggestProvider retorna o provedor comum que o domínio provavelmente
+// pretendia, ou "" se o domínio não se parece com nenhum. Compara o nome
+// (ex.: "gmail") e o sufixo (ex.: "com.br") separadamente: um erro no nome só
+// é apontado com o sufixo idêntico, e um erro no sufixo, com o nome idêntico.
+func suggestProvider(domain string) string {
+	label, suffix, ok := strings.Cut(domain, ".")
+	if !ok {
+		return ""
+	}
+	for _, provider := range commonProviders {
+		if domain == provider {
+			return ""
+		}
+	}
+
+	// Sufixos reais, como yahoo.com.ar ou hotmail.fr, não são erros
+	tld := suffix[strings.LastIndexByte(suffix, '.')+1:]
+	for _, provider := range commonProviders {
+		providerLabel, providerSuffix, _ := strings.Cut(provider, ".")
+		if suffix == providerSuffix && isLabelTypo(label, providerLabel) {
+			return provider
+		}
+		if label == providerLabel && !knownTLDs[tld] && editDistance(suffix, providerSuffix) == 1 {
+			return provider
+		}
+	}
+	return ""
+}
+
+// knownTLDs são domínios de topo comuns: um sufixo terminado neles não é
+// tratado como erro de digitação (ex.: yahoo.com.ar, outlook.pt)
+var knownTLDs = map[string]bool{
+	"com": true, "net": true, "org": true, "edu": true, "gov": true, "io": true, "me": true,
+	"br": true, "pt": true, "ar": true, "cl": true, "uy": true, "py": true, "mx": true, "co": true,
+	"us": true, "ca": true, "uk": true, "fr": true, "de": true, "es": true, "it": true,
+}
+
+// isLabelTypo indica se label é um erro de digitação de provider. Nomes
+// curtos admitem apenas letras vizinhas trocadas ("gmial"), uma letra omitida
+// fora do início ("gmai") ou uma letra repetida ("gmaill"), pois uma letra
+// diferente costuma formar outro domínio legítimo (fmail, globe, lime).
+// Nomes com menos de 5 letras (live, uol, msn) não são comparados.
+func isLabelTypo(label, provider string) bool {
+	if len(provider) < 5 || label == provider {
+		return false
+	}
+	if len(provider) >= 7 {
+		return editDistance(label, provider) == 1
+	}
+
+	switch len(label) - len(provider) {
+	case 0:
+		return isTransposition(label, provider)
+	case -1:
+		// Omissão: remover uma letra de provider, exceto a primeira, resulta em label
+		for i := 1; i < len(provider); i++ {
+			if provider[:i]+provider[i+1:] == label {
+				return true
+			}
+		}
+	case 1:
+		// Repetição: remover uma letra repetida de label resulta em provider
+		for i := 1; i < len(label); i++ {
+			if label[i] == label[i-1] && label[:i]+label[i+1:] == provider {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isTransposition indica se a e b diferem apenas por duas letras vizinhas trocadas
+func isTransposition(a, b string) bool {
+	for i := 0; i < len(a)-1; i++ {
+		if a[i] != b[i] {
+			return a[i] == b[i+1] && a[i+1] == b[i] && a[i+2:] == b[i+2:]
+		}
+	}
+	return false
+}
+
+// editDistance calcula a distância de edição entre a e b (inserções, remoções,
+// substituições e trocas de letras vizinhas, como em "gmial")
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	// Três linhas da matriz: a anterior à anterior, a anterior e a atual
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+	return prev[len(rb)]
+}
+
+// receivesMail indica se o domínio recebe emails. Uma falha do DNS que não
+// seja "domínio inexistente" não rejeita o registro nem é guardada; se ctx
+// terminar, retorna ctx.Err().
+func (c *emailChecker) receivesMail(ctx context.Context, domain string) (bool, error) {
+	c.mu.Lock()
+	ok, cached := c.mx[domain]
+	c.mu.Unlock()
+	if cached {
+		return ok, nil
+	}
+
+	ok, err := c.lookupMail(ctx, domain)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return false, ctxErr
+		}
+		return true, nil
+	}
+
+	c.mu.Lock()
+	c.mx[domain] = ok
+	c.mu.Unlock()
+	return ok, nil
+}
+
+func (c *emailChecker) lookupMail(ctx context.Context, domain string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	records, err := c.resolver.LookupMX(ctx, domain)
+	if err != nil && !isNotFound(err) {
+		return false, err
+	}
+	for _, mx := range records {
+		// "." é o MX nulo (RFC 7505): o domínio declara que não recebe emails
+		if mx.Host != "." && mx.Host != "" {
+			return true, nil
+		}
+	}
+	if len(records) > 0 {
+		return false, nil
+	}
+
+	// Sem MX, o email é entregue no endereço do próprio domínio (RFC 5321, seção 5)
+	addrs, err := c.resolver.LookupHost(ctx, domain)
+	if err != nil && !isNotFound(err) {
+		return false, err
+	}
+	return len(addrs) > 0, nil
+}
+
+// isNotFound indica se err é uma resposta definitiva de domínio ou registro inexistente
+func isNotFound(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
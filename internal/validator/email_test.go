@@ -0,0 +1,310 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/seu-usuario/worker-pool-csv-processor/internal/models"
+)
+
+// fakeResolver responde com registros fixos por domínio e conta as consultas
+type fakeResolver struct {
+	mx    map[string][]*net.MX
+	hosts map[string][]string
+	err   error
+
+	mu    sync.Mutex
+	calls int
+}
+
+func (r *fakeResolver) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	r.mu.Lock()
+	r.calls++
+	r.mu.Unlock()
+	if r.err != nil {
+		return nil, r.err
+	}
+	if records, ok := r.mx[name]; ok {
+		return records, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func (r *fakeResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	if addrs, ok := r.hosts[host]; ok {
+		return addrs, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+func emailValidator(t *testing.T, opts EmailOptions) *Validator {
+	t.Helper()
+
+	v, err := NewValidatorWithOptions(Options{Email: &opts})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return v
+}
+
+func TestNormalizeEmail(t *testing.T) {
+	tests := []struct {
+		email    string
+		expected string
+	}{
+		{"joao@empresa.com", "joao@empresa.com"},
+		{"  Joao.Silva@Empresa.COM.br ", "Joao.Silva@empresa.com.br"},
+		{"ana@bücher.de", "ana@xn--bcher-kva.de"},
+		{"ana@CAFÉ.com.br", "ana@xn--caf-dma.com.br"},
+	}
+
+	for _, tt := range tests {
+		got, err := NormalizeEmail(tt.email)
+		if err != nil {
+			t.Errorf("NormalizeEmail(%q) returned error %v", tt.email, err)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("NormalizeEmail(%q) = %q, expected %q", tt.email, got, tt.expected)
+		}
+	}
+
+	for _, email := range []string{"", "joao", "@empresa.com", "joao@", "joao@empresa..com"} {
+		if _, err := NormalizeEmail(email); err == nil {
+			t.Errorf("Expected error for %q", email)
+		}
+	}
+}
+
+func TestValidate_EmailNormalization(t *testing.T) {
+	v := emailValidator(t, EmailOptions{})
+
+	record := validRecord()
+	record.Email = "Joao@Exâmplo.com.br"
+	if err := v.Validate(record); err != nil {
+		t.Fatalf("Expected no error for IDN domain, got %v", err)
+	}
+	if record.Email != "Joao@xn--exmplo-xta.com.br" {
+		t.Errorf("Expected normalized email, got %q", record.Email)
+	}
+
+	// Sem o modo estendido, o domínio não ASCII é rejeitado pelo padrão
+	record = validRecord()
+	record.Email = "joao@exâmplo.com.br"
+	if err := NewValidator().Validate(record); !errors.Is(err, models.ErrPattern) {
+		t.Errorf("Expected pattern error, got %v", err)
+	}
+}
+
+func TestValidate_EmailChecks(t *testing.T) {
+	v := emailValidator(t, EmailOptions{DisposableDomains: []string{"Descartavel.com.br"}})
+
+	tests := []struct {
+		email      string
+		code       string
+		warning    bool
+		suggestion string
+	}{
+		{"joao@empresa.com", "", false, ""},
+		{"joao@gmail.com", "", false, ""},
+		{"joao@uol.com.br", "", false, ""},
+		{"joao@mailinator.com", models.CodeDisposable, false, ""},
+		{"joao@caixa.yopmail.com", models.CodeDisposable, false, ""},
+		{"joao@descartavel.com.br", models.CodeDisposable, false, ""},
+		{"joao@gmial.com", models.CodeTypo, true, "joao@gmail.com"},
+		{"joao@gmai.com", models.CodeTypo, true, "joao@gmail.com"},
+		{"joao@gmaill.com", models.CodeTypo, true, "joao@gmail.com"},
+		{"Joao@GMAIL.CON", models.CodeTypo, true, "Joao@gmail.com"},
+		{"joao@hotmal.com", models.CodeTypo, true, "joao@hotmail.com"},
+		{"joao@outlok.com.br", models.CodeTypo, true, "joao@outlook.com.br"},
+		{"joao@yaho.com.br", models.CodeTypo, true, "joao@yahoo.com.br"},
+		{"joao@hotmail.com.bt", models.CodeTypo, true, "joao@hotmail.com.br"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.email, func(t *testing.T) {
+			record := validRecord()
+			record.Email = tt.email
+
+			warnings, err := v.Check(context.Background(), record)
+			validationErrs := models.ValidationErrorsOf(err)
+			if tt.code == "" {
+				if len(validationErrs) != 0 || len(warnings) != 0 {
+					t.Errorf("Expected no error or warning, got %v / %v", validationErrs, warnings)
+				}
+				return
+			}
+
+			found := validationErrs
+			if tt.warning {
+				found = warnings
+				if err != nil {
+					t.Errorf("Expected only a warning, got error %v", err)
+				}
+			}
+			if len(found) != 1 {
+				t.Fatalf("Expected 1 %s, got errors %v / warnings %v", tt.code, validationErrs, warnings)
+			}
+			if e := found[0]; e.Field != "email" || e.Code != tt.code || e.Suggestion != tt.suggestion {
+				t.Errorf("Expected %s with suggestion %q, got %+v", tt.code, tt.suggestion, e)
+			}
+		})
+	}
+}
+
+func TestSuggestProvider_LegitimateDomains(t *testing.T) {
+	// Domínios legítimos parecidos com provedores comuns não são erros de digitação
+	for _, domain := range []string{
+		"life.com", "line.com", "hive.com", "lime.com", "live.com.pt",
+		"fmail.com", "email.com", "ymail.com", "mail.com",
+		"sol.com.br", "uol.com.br", "bol.com.br", "globe.com", "lobo.com", "glob.com.br",
+		"yahoo.com.ar", "yahoo.co.uk", "hotmail.fr", "outlook.pt", "gmail.co",
+		"msn.com", "aol.com", "mns.com", "empresa.com.br",
+	} {
+		if provider := suggestProvider(domain); provider != "" {
+			t.Errorf("Expected no suggestion for %s, got %s", domain, provider)
+		}
+	}
+}
+
+func TestValidate_EmailRejectTypos(t *testing.T) {
+	v := emailValidator(t, EmailOptions{RejectTypos: true})
+
+	record := validRecord()
+	record.Email = "joao@gmial.com"
+	warnings, err := v.Check(context.Background(), record)
+	if !errors.Is(err, models.ErrTypo) || len(warnings) != 0 {
+		t.Errorf("Expected ErrTypo as an error, got %v / warnings %v", err, warnings)
+	}
+}
+
+func TestValidate_EmailChecksSkipInvalidFormat(t *testing.T) {
+	v := emailValidator(t, EmailOptions{})
+
+	record := validRecord()
+	record.Email = "joao.gmial.com"
+	validationErrs := models.ValidationErrorsOf(v.Validate(record))
+	if len(validationErrs) != 1 || validationErrs[0].Code != models.CodePattern {
+		t.Errorf("Expected only the pattern error, got %v", validationErrs)
+	}
+}
+
+func TestValidate_EmailMX(t *testing.T) {
+	resolver := &fakeResolver{
+		mx: map[string][]*net.MX{
+			"empresa.com":  {{Host: "mx.empresa.com.", Pref: 10}},
+			"semmail.com":  {{Host: ".", Pref: 0}},
+			"hospedado.io": {},
+		},
+		hosts: map[string][]string{"hospedado.io": {"192.0.2.1"}},
+	}
+	v := emailValidator(t, EmailOptions{Resolver: resolver})
+
+	tests := []struct {
+		email string
+		valid bool
+	}{
+		{"joao@empresa.com", true},
+		{"joao@hospedado.io", true},
+		{"joao@semmail.com", false},
+		{"joao@inexistente.com", false},
+	}
+
+	for _, tt := range tests {
+		record := validRecord()
+		record.Email = tt.email
+		err := v.Validate(record)
+		if tt.valid && err != nil {
+			t.Errorf("%s: expected no error, got %v", tt.email, err)
+		}
+		if !tt.valid && !errors.Is(err, models.ErrNoMX) {
+			t.Errorf("%s: expected ErrNoMX, got %v", tt.email, err)
+		}
+	}
+
+	// Respostas são guardadas por domínio
+	calls := resolver.calls
+	for i := 0; i < 3; i++ {
+		record := validRecord()
+		record.Email = "maria@empresa.com"
+		v.Validate(record)
+	}
+	if resolver.calls != calls {
+		t.Errorf("Expected cached MX answers, got %d new lookups", resolver.calls-calls)
+	}
+}
+
+func TestValidate_EmailMXTemporaryFailure(t *testing.T) {
+	resolver := &fakeResolver{err: &net.DNSError{Err: "timeout", Name: "empresa.com", IsTimeout: true}}
+	v := emailValidator(t, EmailOptions{Resolver: resolver})
+
+	for i := 0; i < 2; i++ {
+		if err := v.Validate(validRecord()); err != nil {
+			t.Errorf("Expected temporary DNS failure not to reject the record, got %v", err)
+		}
+	}
+	if resolver.calls != 2 {
+		t.Errorf("Expected failures not to be cached, got %d lookups", resolver.calls)
+	}
+}
+
+func TestValidateContext_MXLookupCancelled(t *testing.T) {
+	resolver := &blockingResolver{}
+	v := emailValidator(t, EmailOptions{Resolver: resolver, LookupTimeout: time.Minute})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := v.ValidateContext(ctx, validRecord())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the lookup to stop with ctx, took %v", elapsed)
+	}
+}
+
+// blockingResolver responde apenas quando o contexto termina, como um DNS lento
+type blockingResolver struct{}
+
+func (blockingResolver) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	<-ctx.Done()
+	return nil, &net.DNSError{Err: ctx.Err().Error(), Name: name, IsTimeout: true}
+}
+
+func (blockingResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestNewValidatorWithOptions_InvalidDisposableDomain(t *testing.T) {
+	opts := EmailOptions{DisposableDomains: []string{"temp..com"}}
+	if _, err := NewValidatorWithOptions(Options{Email: &opts}); err == nil {
+		t.Error("Expected error for invalid disposable domain, got nil")
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"gmail.com", "gmail.com", 0},
+		{"gmial.com", "gmail.com", 1},
+		{"gmai.com", "gmail.com", 1},
+		{"gmaill.com", "gmail.com", 1},
+		{"gnail.con", "gmail.com", 2},
+		{"", "abc", 3},
+	}
+
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.expected {
+			t.Errorf("editDistance(%q, %q) = %d, expected %d", tt.a, tt.b, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Parâmetros do Punycode (RFC 3492, seção 5)
+const (
+	punyBase        = 36
+	punyTMin        = 1
+	punyTMax        = 26
+	punySkew        = 38
+	punyDamp        = 700
+	punyInitialBias = 72
+	punyInitialN    = 128
+	// acePrefix marca um rótulo de domínio codificado em Punycode
+	acePrefix = "xn--"
+)
+
+var errPunycodeOverflow = errors.New("punycode: overflow")
+
+// ToASCII converte um domínio internacionalizado (IDN) para a forma ASCII
+// usada no DNS: rótulos com caracteres não ASCII são codificados em Punycode
+// com o prefixo "xn--" e o domínio é convertido para minúsculas
+// (ex.: "Bücher.de" → "xn--bcher-kva.de"). Não aplica a normalização Unicode
+// completa do IDNA2008, que exigiria tabelas fora da biblioteca padrão.
+func ToASCII(domain string) (string, error) {
+	// Pontos ideográficos também separam rótulos
+	domain = strings.NewReplacer("。", ".", "．", ".", "｡", ".").Replace(domain)
+	domain = strings.TrimSuffix(strings.ToLower(domain), ".")
+	if domain == "" {
+		return "", fmt.Errorf("domínio vazio")
+	}
+
+	labels := strings.Split(domain, ".")
+	for i, label := range labels {
+		if label == "" {
+			return "", fmt.Errorf("domínio com rótulo vazio: %q", domain)
+		}
+		if !isASCII(label) {
+			encoded, err := punycodeEncode(label)
+			if err != nil {
+				return "", err
+			}
+			label = acePrefix + encoded
+		}
+		if len(label) > 63 {
+			return "", fmt.Errorf("rótulo do domínio com mais de 63 caracteres: %q", label)
+		}
+		labels[i] = label
+	}
+
+	ascii := strings.Join(labels, ".")
+	if len(ascii) > 253 {
+		return "", fmt.Errorf("domínio com mais de 253 caracteres")
+	}
+	return ascii, nil
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// punycodeEncode codifica um rótulo em Punycode, sem o prefixo "xn--" (RFC 3492, seção 6.3)
+func punycodeEncode(label string) (string, error) {
+	runes := []rune(label)
+
+	var out strings.Builder
+	for _, r := range runes {
+		if r < utf8.RuneSelf {
+			out.WriteRune(r)
+		}
+	}
+	basic := out.Len()
+	handled := basic
+	if basic > 0 {
+		out.WriteByte('-')
+	}
+
+	n, delta, bias := rune(punyInitialN), 0, punyInitialBias
+	for handled < len(runes) {
+		// Próximo code point a codificar: o menor ainda não tratado
+		m := rune(utf8.MaxRune + 1)
+		for _, r := range runes {
+			if r >= n && r < m {
+				m = r
+			}
+		}
+
+		increment := int(m-n) * (handled + 1)
+		if increment < 0 || delta > maxInt-increment {
+			return "", errPunycodeOverflow
+		}
+		delta += increment
+		n = m
+
+		for _, r := range runes {
+			if r < n {
+				delta++
+			}
+			if r != n {
+				continue
+			}
+
+			q := delta
+			for k := punyBase; ; k += punyBase {
+				t := k - bias
+				if t < punyTMin {
+					t = punyTMin
+				} else if t > punyTMax {
+					t = punyTMax
+				}
+				if q < t {
+					break
+				}
+				out.WriteByte(punycodeDigit(t + (q-t)%(punyBase-t)))
+				q = (q - t) / (punyBase - t)
+			}
+			out.WriteByte(punycodeDigit(q))
+
+			bias = punycodeAdapt(delta, handled+1, handled == basic)
+			delta = 0
+			handled++
+		}
+		delta++
+		n++
+	}
+	return out.String(), nil
+}
+
+const maxInt = int(^uint(0) >> 1)
+
+// punycodeDigit converte um dígito (0-35) para a-z e 0-9
+func punycodeDigit(d int) byte {
+	if d < 26 {
+		return byte('a' + d)
+	}
+	return byte('0' + d - 26)
+}
+
+// punycodeAdapt recalcula o bias após cada code point (RFC 3492, seção 6.1)
+func punycodeAdapt(delta, numPoints int, first bool) int {
+	if first {
+		delta /= punyDamp
+	} else {
+		delta /= 2
+	}
+	delta += delta / numPoints
+
+	k := 0
+	for delta > ((punyBase-punyTMin)*punyTMax)/2 {
+		delta /= punyBase - punyTMin
+		k += punyBase
+	}
+	return k + (punyBase-punyTMin+1)*delta/(delta+punySkew)
+}
@@ -0,0 +1,39 @@
+package validator
+
+import "testing"
+
+func TestToASCII(t *testing.T) {
+	tests := []struct {
+		domain   string
+		expected string
+	}{
+		{"empresa.com.br", "empresa.com.br"},
+		{"Empresa.COM.", "empresa.com"},
+		{"bücher.de", "xn--bcher-kva.de"},
+		{"MÜNCHEN.de", "xn--mnchen-3ya.de"},
+		{"exemplo。com", "exemplo.com"},
+		// RFC 3492, seção 7.1, exemplo (B): chinês simplificado
+		{"他们为什么不说中文.cn", "xn--ihqwcrb4cv8a8dqg056pqjye.cn"},
+		// RFC 3492, seção 7.1, exemplo (A): árabe
+		{"ليهمابتكلموشعربي؟", "xn--egbpdaj6bu4bxfgehfvwxn"},
+	}
+
+	for _, tt := range tests {
+		got, err := ToASCII(tt.domain)
+		if err != nil {
+			t.Errorf("ToASCII(%q) returned error %v", tt.domain, err)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("ToASCII(%q) = %q, expected %q", tt.domain, got, tt.expected)
+		}
+	}
+}
+
+func TestToASCII_Invalid(t *testing.T) {
+	for _, domain := range []string{"", ".", "empresa..com", makeString(64) + ".com"} {
+		if got, err := ToASCII(domain); err == nil {
+			t.Errorf("Expected error for %q, got %q", domain, got)
+		}
+	}
+}
@@ -0,0 +1,307 @@
+package validator
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/seu-usuario/worker-pool-csv-processor/internal/models"
+)
+
+// ErrInvalidRules indica um arquivo de regras malformado ou incoerente
+var ErrInvalidRules = errors.New("regras de validação inválidas")
+
+//go:embed default_rules.json
+var defaultRulesJSON []byte
+
+// fields são os campos do registro que podem ter regras, na ordem em que são validados
+var fields = []string{"name", "email", "age", "salary", "department", "is_active", "created_at"}
+
+// numericFields são os campos que aceitam min e max
+var numericFields = map[string]bool{"age": true, "salary": true}
+
+// Rules é o conjunto de regras de validação, normalmente carregado de um
+// arquivo JSON com LoadRules. Os campos usam os nomes do CSV (name, email,
+// age, salary, department, is_active, created_at).
+type Rules struct {
+	// Fields associa cada campo às suas regras
+	Fields map[string]*FieldRule `json:"fields"`
+	// Conditions são regras entre campos, aplicadas na ordem do arquivo
+	Conditions []*Condition `json:"conditions,omitempty"`
+}
+
+// FieldRule são as restrições de um campo. Regras omitidas não são verificadas.
+type FieldRule struct {
+	// Required rejeita valores vazios (ou só com espaços); sem ele, um valor
+	// vazio dispensa as demais regras do campo
+	Required bool `json:"required,omitempty"`
+	// Min e Max limitam campos numéricos (age e salary), inclusive
+	Min *float64 `json:"min,omitempty"`
+	Max *float64 `json:"max,omitempty"`
+	// MinLength e MaxLength limitam o número de caracteres do texto
+	MinLength *int `json:"min_length,omitempty"`
+	MaxLength *int `json:"max_length,omitempty"`
+	// Pattern é uma expressão regular (sintaxe RE2) que o valor deve satisfazer
+	Pattern string `json:"pattern,omitempty"`
+	// Enum lista os valores aceitos
+	Enum []string `json:"enum,omitempty"`
+	// Message substitui a mensagem padrão das violações do campo
+	Message string `json:"message,omitempty"`
+
+	pattern *regexp.Regexp
+	enum    map[string]bool
+}
+
+// Condition aplica as regras de Then apenas aos registros que satisfazem
+// todas as regras de When (campos vazios não as satisfazem).
+// Exemplo: salário mínimo maior para um departamento.
+type Condition struct {
+	// Name identifica a condição nas mensagens de erro
+	Name string                `json:"name,omitempty"`
+	When map[string]*FieldRule `json:"when"`
+	Then map[string]*FieldRule `json:"then"`
+}
+
+// DefaultRules retorna as regras padrão do processador
+func DefaultRules() *Rules {
+	rules, err := ParseRules(defaultRulesJSON)
+	if err != nil {
+		panic(fmt.Sprintf("default_rules.json: %v", err))
+	}
+	return rules
+}
+
+// LoadRules lê as regras de um arquivo JSON
+func LoadRules(path string) (*Rules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler regras de validação: %w", err)
+	}
+
+	rules, err := ParseRules(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return rules, nil
+}
+
+// ParseRules interpreta regras em JSON. Campos ou chaves desconhecidos,
+// expressões regulares inválidas e limites incoerentes retornam ErrInvalidRules.
+func ParseRules(data []byte) (*Rules, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var rules Rules
+	if err := decoder.Decode(&rules); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRules, err)
+	}
+	if err := rules.compile(); err != nil {
+		return nil, err
+	}
+	return &rules, nil
+}
+
+// compile verifica as regras e prepara expressões regulares e enums
+func (r *Rules) compile() error {
+	if err := compileFields(r.Fields, "fields"); err != nil {
+		return err
+	}
+
+	for i, condition := range r.Conditions {
+		where := fmt.Sprintf("conditions[%d]", i)
+		if condition.Name != "" {
+			where = fmt.Sprintf("condição %q", condition.Name)
+		}
+		if len(condition.When) == 0 || len(condition.Then) == 0 {
+			return fmt.Errorf("%w: %s: when e then são obrigatórios", ErrInvalidRules, where)
+		}
+		if err := compileFields(condition.When, where+".when"); err != nil {
+			return err
+		}
+		if err := compileFields(condition.Then, where+".then"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func compileFields(rules map[string]*FieldRule, where string) error {
+	for field, rule := range rules {
+		if rule == nil {
+			return fmt.Errorf("%w: %s.%s: regra vazia", ErrInvalidRules, where, field)
+		}
+		if err := rule.compile(field); err != nil {
+			return fmt.Errorf("%w: %s.%s: %v", ErrInvalidRules, where, field, err)
+		}
+	}
+	return nil
+}
+
+func (rule *FieldRule) compile(field string) error {
+	if !isField(field) {
+		return fmt.Errorf("campo desconhecido (use %s)", strings.Join(fields, ", "))
+	}
+	if (rule.Min != nil || rule.Max != nil) && !numericFields[field] {
+		return fmt.Errorf("min e max só se aplicam a age e salary")
+	}
+	if rule.Min != nil && rule.Max != nil && *rule.Min > *rule.Max {
+		return fmt.Errorf("min (%v) maior que max (%v)", *rule.Min, *rule.Max)
+	}
+	if (rule.MinLength != nil && *rule.MinLength < 0) || (rule.MaxLength != nil && *rule.MaxLength < 0) {
+		return fmt.Errorf("min_length e max_length não podem ser negativos")
+	}
+	if rule.MinLength != nil && rule.MaxLength != nil && *rule.MinLength > *rule.MaxLength {
+		return fmt.Errorf("min_length (%d) maior que max_length (%d)", *rule.MinLength, *rule.MaxLength)
+	}
+
+	if rule.Pattern != "" {
+		pattern, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return fmt.Errorf("pattern inválido: %v", err)
+		}
+		rule.pattern = pattern
+	}
+
+	if len(rule.Enum) > 0 {
+		rule.enum = make(map[string]bool, len(rule.Enum))
+		for _, value := range rule.Enum {
+			rule.enum[value] = true
+		}
+	}
+	return nil
+}
+
+func isField(field string) bool {
+	for _, f := range fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldValue é o valor de um campo do registro
+type fieldValue struct {
+	// raw é o valor original, usado em ValidationError.Value
+	raw interface{}
+	// text é o valor como texto, usado em required, tamanho, pattern e enum
+	text string
+	// number é o valor dos campos numéricos
+	number float64
+}
+
+// valueOf retorna o valor do campo no registro
+func valueOf(record *models.Record, field string) fieldValue {
+	switch field {
+	case "name":
+		return textValue(record.Name)
+	case "email":
+		return textValue(record.Email)
+	case "age":
+		return fieldValue{raw: record.Age, text: strconv.Itoa(record.Age), number: float64(record.Age)}
+	case "salary":
+		return fieldValue{raw: record.Salary, text: strconv.FormatFloat(record.Salary, 'f', -1, 64), number: record.Salary}
+	case "department":
+		return textValue(record.Department)
+	case "is_active":
+		return fieldValue{raw: record.IsActive, text: strconv.FormatBool(record.IsActive)}
+	case "created_at":
+		if record.CreatedAt.IsZero() {
+			return fieldValue{raw: record.CreatedAt}
+		}
+		return fieldValue{raw: record.CreatedAt, text: record.CreatedAt.Format(time.DateOnly)}
+	default:
+		return fieldValue{}
+	}
+}
+
+// textValue ignora espaços nas extremidades do texto
+func textValue(s string) fieldValue {
+	return fieldValue{raw: s, text: strings.TrimSpace(s)}
+}
+
+// violation é uma regra violada: o código (models.Code*), a mensagem e,
+// se houver, uma sugestão de correção. Um aviso não invalida o registro.
+type violation struct {
+	code       string
+	message    string
+	suggestion string
+	warning    bool
+}
+
+// check retorna as regras violadas pelo valor
+func (rule *FieldRule) check(field string, value fieldValue) []violation {
+	if value.text == "" {
+		if rule.Required {
+			return []violation{rule.violation(models.CodeRequired, "campo obrigatório")}
+		}
+		return nil
+	}
+
+	var violations []violation
+
+	if numericFields[field] && !rule.inRange(value.number) {
+		violations = append(violations, rule.violation(models.CodeRange, rangeMessage(rule.Min, rule.Max)))
+	}
+
+	length := utf8.RuneCountInString(value.text)
+	if (rule.MinLength != nil && length < *rule.MinLength) || (rule.MaxLength != nil && length > *rule.MaxLength) {
+		violations = append(violations, rule.violation(models.CodeLength, lengthMessage(rule.MinLength, rule.MaxLength)))
+	}
+
+	if rule.pattern != nil && !rule.pattern.MatchString(value.text) {
+		violations = append(violations, rule.violation(models.CodePattern, "formato inválido"))
+	}
+
+	if rule.enum != nil && !rule.enum[value.text] {
+		violations = append(violations, rule.violation(models.CodeEnum, "valor não permitido (aceitos: "+strings.Join(rule.Enum, ", ")+")"))
+	}
+
+	return violations
+}
+
+func (rule *FieldRule) inRange(number float64) bool {
+	return (rule.Min == nil || number >= *rule.Min) && (rule.Max == nil || number <= *rule.Max)
+}
+
+// violation cria a violação com a mensagem personalizada da regra, se houver
+func (rule *FieldRule) violation(code, message string) violation {
+	if rule.Message != "" {
+		message = rule.Message
+	}
+	return violation{code: code, message: message}
+}
+
+func rangeMessage(min, max *float64) string {
+	switch {
+	case min != nil && max != nil:
+		return fmt.Sprintf("fora do range válido (%s-%s)", formatNumber(*min), formatNumber(*max))
+	case min != nil:
+		return fmt.Sprintf("deve ser no mínimo %s", formatNumber(*min))
+	default:
+		return fmt.Sprintf("deve ser no máximo %s", formatNumber(*max))
+	}
+}
+
+func lengthMessage(min, max *int) string {
+	switch {
+	case min != nil && max != nil:
+		return fmt.Sprintf("deve ter entre %d e %d caracteres", *min, *max)
+	case min != nil:
+		return fmt.Sprintf("deve ter pelo menos %d caracteres", *min)
+	default:
+		return fmt.Sprintf("deve ter no máximo %d caracteres", *max)
+	}
+}
+
+func formatNumber(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
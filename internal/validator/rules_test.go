@@ -0,0 +1,256 @@
+package validator
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/seu-usuario/worker-pool-csv-processor/internal/models"
+)
+
+func validRecord() *models.Record {
+	return &models.Record{
+		Name:       "João Silva",
+		Email:      "joao@empresa.com",
+		Age:        28,
+		Salary:     5500.00,
+		Department: "TI",
+		IsActive:   true,
+		CreatedAt:  time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+		RowNumber:  7,
+	}
+}
+
+func mustValidator(t *testing.T, rulesJSON string) *Validator {
+	t.Helper()
+
+	rules, err := ParseRules([]byte(rulesJSON))
+	if err != nil {
+		t.Fatalf("Expected valid rules, got %v", err)
+	}
+	v, err := NewValidatorWithRules(rules)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return v
+}
+
+func TestValidate_StructuredErrors(t *testing.T) {
+	v := NewValidator()
+	record := validRecord()
+	record.Age = 17
+	record.Department = "Recursos Humanos"
+
+	err := v.Validate(record)
+
+	var validationErrs models.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		t.Fatalf("Expected models.ValidationErrors, got %T", err)
+	}
+	if len(validationErrs) != 2 {
+		t.Fatalf("Expected 2 errors, got %v", validationErrs)
+	}
+	if e := validationErrs[0]; e.Field != "age" || e.Code != models.CodeRange || e.Value != 17 || e.RowNumber != 7 {
+		t.Errorf("Unexpected age error: %+v", e)
+	}
+	if e := validationErrs[1]; e.Field != "department" || e.Code != models.CodeEnum || e.Value != "Recursos Humanos" {
+		t.Errorf("Unexpected department error: %+v", e)
+	}
+}
+
+func TestValidate_ErrorsIs(t *testing.T) {
+	v := NewValidator()
+	record := validRecord()
+	record.Email = "joao.empresa.com"
+	record.Name = ""
+
+	err := v.Validate(record)
+
+	tests := []struct {
+		name     string
+		target   error
+		expected bool
+	}{
+		{"pattern code", models.ErrPattern, true},
+		{"required code", models.ErrRequired, true},
+		{"range code", models.ErrRange, false},
+		{"field only", &models.ValidationError{Field: "email"}, true},
+		{"field and code", &models.ValidationError{Field: "name", Code: models.CodeRequired}, true},
+		{"field with other code", &models.ValidationError{Field: "email", Code: models.CodeRequired}, false},
+		{"other field", &models.ValidationError{Field: "age"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errors.Is(err, tt.target); got != tt.expected {
+				t.Errorf("errors.Is = %v, expected %v (err: %v)", got, tt.expected, err)
+			}
+		})
+	}
+
+	var validationErrs models.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		t.Fatalf("Expected models.ValidationErrors, got %T", err)
+	}
+	if fields := validationErrs.Fields(); len(fields) != 2 || fields[0] != "name" || fields[1] != "email" {
+		t.Errorf("Expected fields [name email], got %v", fields)
+	}
+}
+
+func TestValidate_CustomRules(t *testing.T) {
+	v := mustValidator(t, `{
+		"fields": {
+			"age": {"min": 16},
+			"email": {"required": true, "pattern": "@empresa\\.com$", "message": "use o email corporativo"},
+			"is_active": {"enum": ["true"]}
+		}
+	}`)
+
+	record := validRecord()
+	record.Age = 16
+	record.Salary = 1 // sem regra para salary
+	if err := v.Validate(record); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	record.Email = "joao@gmail.com"
+	record.IsActive = false
+	validationErrs := models.ValidationErrorsOf(v.Validate(record))
+	if len(validationErrs) != 2 {
+		t.Fatalf("Expected 2 errors, got %v", validationErrs)
+	}
+	if validationErrs[0].Field != "email" || validationErrs[0].Message != "use o email corporativo" {
+		t.Errorf("Expected custom email message, got %+v", validationErrs[0])
+	}
+	if validationErrs[1].Field != "is_active" || validationErrs[1].Value != false {
+		t.Errorf("Unexpected is_active error: %+v", validationErrs[1])
+	}
+}
+
+func TestValidate_OptionalEmptyFieldSkipsRules(t *testing.T) {
+	v := mustValidator(t, `{"fields": {"department": {"enum": ["TI"]}}}`)
+
+	record := validRecord()
+	record.Department = "  "
+	if err := v.Validate(record); err != nil {
+		t.Errorf("Expected no error for empty optional field, got %v", err)
+	}
+}
+
+func TestValidate_Conditions(t *testing.T) {
+	v := mustValidator(t, `{
+		"fields": {},
+		"conditions": [
+			{
+				"name": "salário mínimo de TI",
+				"when": {"department": {"enum": ["TI"]}},
+				"then": {"salary": {"min": 5000}}
+			},
+			{
+				"when": {"is_active": {"enum": ["false"]}, "age": {"max": 20}},
+				"then": {"name": {"max_length": 5}}
+			}
+		]
+	}`)
+
+	tests := []struct {
+		name   string
+		modify func(r *models.Record)
+		fields []string
+	}{
+		{"condition met and satisfied", func(r *models.Record) {}, nil},
+		{"condition met and violated", func(r *models.Record) { r.Salary = 4000 }, []string{"salary"}},
+		{"condition not met", func(r *models.Record) { r.Department = "RH"; r.Salary = 4000 }, nil},
+		{"empty field does not meet condition", func(r *models.Record) { r.Department = ""; r.Salary = 4000 }, nil},
+		{"all when rules must match", func(r *models.Record) { r.IsActive = false; r.Age = 30 }, nil},
+		{"second condition", func(r *models.Record) { r.IsActive = false; r.Age = 19 }, []string{"name"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record := validRecord()
+			tt.modify(record)
+
+			validationErrs := models.ValidationErrorsOf(v.Validate(record))
+			if len(validationErrs) != len(tt.fields) {
+				t.Fatalf("Expected errors on %v, got %v", tt.fields, validationErrs)
+			}
+			for i, field := range tt.fields {
+				if validationErrs[i].Field != field {
+					t.Errorf("Expected error on %s, got %+v", field, validationErrs[i])
+				}
+			}
+		})
+	}
+
+	record := validRecord()
+	record.Salary = 4000
+	if err := v.Validate(record); err == nil || !strings.Contains(err.Error(), "regra: salário mínimo de TI") {
+		t.Errorf("Expected condition name in message, got %v", err)
+	}
+}
+
+func TestParseRules_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"malformed JSON":     `{"fields": `,
+		"unknown key":        `{"fields": {"age": {"minimum": 1}}}`,
+		"unknown field":      `{"fields": {"idade": {"min": 1}}}`,
+		"min on text":        `{"fields": {"name": {"min": 1}}}`,
+		"min above max":      `{"fields": {"age": {"min": 10, "max": 5}}}`,
+		"negative length":    `{"fields": {"name": {"min_length": -1}}}`,
+		"min_length above":   `{"fields": {"name": {"min_length": 10, "max_length": 5}}}`,
+		"invalid pattern":    `{"fields": {"email": {"pattern": "("}}}`,
+		"null rule":          `{"fields": {"email": null}}`,
+		"condition sem then": `{"fields": {}, "conditions": [{"when": {"age": {"min": 1}}}]}`,
+		"invalid in when":    `{"fields": {}, "conditions": [{"when": {"foo": {}}, "then": {"age": {"min": 1}}}]}`,
+		"invalid in then":    `{"fields": {}, "conditions": [{"when": {"age": {}}, "then": {"name": {"max": 1}}}]}`,
+	}
+
+	for name, rulesJSON := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseRules([]byte(rulesJSON)); !errors.Is(err, ErrInvalidRules) {
+				t.Errorf("Expected ErrInvalidRules, got %v", err)
+			}
+		})
+	}
+}
+
+func TestLoadRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "regras.json")
+	if err := os.WriteFile(path, []byte(`{"fields": {"age": {"min": 21}}}`), 0644); err != nil {
+		t.Fatalf("Failed to write rules: %v", err)
+	}
+
+	rules, err := LoadRules(path)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if rules.Fields["age"] == nil || *rules.Fields["age"].Min != 21 {
+		t.Errorf("Expected age min 21, got %+v", rules.Fields["age"])
+	}
+
+	if _, err := LoadRules(filepath.Join(t.TempDir(), "inexistente.json")); err == nil {
+		t.Error("Expected error for missing file, got nil")
+	}
+}
+
+func TestNewValidatorWithRules_Invalid(t *testing.T) {
+	max := 1.0
+	rules := &Rules{Fields: map[string]*FieldRule{"name": {Max: &max}}}
+
+	if _, err := NewValidatorWithRules(rules); !errors.Is(err, ErrInvalidRules) {
+		t.Errorf("Expected ErrInvalidRules, got %v", err)
+	}
+}
+
+func TestDefaultRules(t *testing.T) {
+	rules := DefaultRules()
+	for _, field := range []string{"name", "email", "age", "salary", "department"} {
+		if rules.Fields[field] == nil {
+			t.Errorf("Expected default rule for %s", field)
+		}
+	}
+}
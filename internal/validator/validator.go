@@ -1,76 +1,186 @@
 package validator
 
 import (
+	"context"
 	"fmt"
-	"regexp"
-	"strings"
 
 	"github.com/seu-usuario/worker-pool-csv-processor/internal/models"
 )
 
-// Validator valida registros
+// Validator valida registros conforme um conjunto de regras.
+// É seguro para uso concorrente.
 type Validator struct {
-	emailRegex *regexp.Regexp
+	rules       *Rules
+	departments DepartmentLookup
+	email       *emailChecker
 }
 
-// NewValidator cria uma nova instância do validador
+// Options configura um Validator criado com NewValidatorWithOptions
+type Options struct {
+	// Rules são as regras de validação (nil = DefaultRules)
+	Rules *Rules
+	// Departments é o cadastro em que o departamento de cada registro deve
+	// estar ativo (nil = models.DefaultDepartments; use NewDepartmentCache sobre o banco)
+	Departments DepartmentLookup
+	// Email habilita a validação estendida de email (nil = apenas as regras)
+	Email *EmailOptions
+}
+
+// NewValidator cria uma nova instância do validador com as regras padrão,
+// aceitando os departamentos de models.DefaultDepartments
 func NewValidator() *Validator {
-	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}$`)
 	return &Validator{
-		emailRegex: emailRegex,
+		rules:       DefaultRules(),
+		departments: NewStaticDepartments(models.DefaultDepartments...),
+	}
+}
+
+// NewValidatorWithRules cria um validador com as regras informadas
+// (veja LoadRules). Regras incoerentes retornam ErrInvalidRules.
+func NewValidatorWithRules(rules *Rules) (*Validator, error) {
+	return NewValidatorWithOptions(Options{Rules: rules})
+}
+
+// NewValidatorWithOptions cria um validador a partir de Options
+func NewValidatorWithOptions(opts Options) (*Validator, error) {
+	if opts.Rules == nil {
+		opts.Rules = DefaultRules()
 	}
+	if opts.Departments == nil {
+		opts.Departments = NewStaticDepartments(models.DefaultDepartments...)
+	}
+	if err := opts.Rules.compile(); err != nil {
+		return nil, err
+	}
+	v := &Validator{
+		rules:       opts.Rules,
+		departments: opts.Departments,
+	}
+	if opts.Email != nil {
+		email, err := newEmailChecker(*opts.Email)
+		if err != nil {
+			return nil, err
+		}
+		v.email = email
+	}
+	return v, nil
 }
 
-// Validate valida um registro
+// Validate valida um registro. Cada regra violada gera um *models.ValidationError
+// com o campo, o código da regra e o valor; todos são retornados juntos em uma
+// models.ValidationErrors, que pode ser inspecionada com errors.As e errors.Is.
+// Uma falha ao consultar o cadastro de departamentos é retornada encapsulada
+// (com %w), para que o chamador possa identificar erros transitórios do banco.
+// Com Options.Email, o email do registro é normalizado (veja NormalizeEmail)
+// antes das regras.
 func (v *Validator) Validate(record *models.Record) error {
-	var errors []string
+	return v.ValidateContext(context.Background(), record)
+}
+
+// ValidateContext valida um registro como Validate; as consultas ao DNS da
+// validação estendida de email respeitam o cancelamento e o prazo de ctx
+func (v *Validator) ValidateContext(ctx context.Context, record *models.Record) error {
+	_, err := v.Check(ctx, record)
+	return err
+}
 
-	// Validação de email
-	if !v.emailRegex.MatchString(record.Email) {
-		errors = append(errors, fmt.Sprintf("email inválido: %s", record.Email))
+// Check valida um registro como ValidateContext e retorna também os avisos:
+// problemas que não invalidam o registro, como um provável erro de digitação
+// no domínio do email (veja EmailOptions.RejectTypos)
+func (v *Validator) Check(ctx context.Context, record *models.Record) (models.ValidationErrors, error) {
+	if v.email != nil {
+		if normalized, err := NormalizeEmail(record.Email); err == nil {
+			record.Email = normalized
+		}
 	}
 
-	// Validação de idade
-	if record.Age < 18 || record.Age > 100 {
-		errors = append(errors, fmt.Sprintf("idade fora do range válido (18-100): %d", record.Age))
+	var errs, warnings models.ValidationErrors
+	invalid := func(field string, value fieldValue, violations []violation) {
+		for _, violation := range violations {
+			target := &errs
+			if violation.warning {
+				target = &warnings
+			}
+			*target = append(*target, &models.ValidationError{
+				RowNumber:  record.RowNumber,
+				Field:      field,
+				Code:       violation.code,
+				Message:    violation.message,
+				Value:      value.raw,
+				Suggestion: violation.suggestion,
+				Source:     record.Source,
+			})
+		}
 	}
 
-	// Validação de salário
-	if record.Salary < 1000 || record.Salary > 1000000 {
-		errors = append(errors, fmt.Sprintf("salário fora do range válido (1000-1000000): %.2f", record.Salary))
+	// Regras de cada campo
+	for _, field := range fields {
+		if rule := v.rules.Fields[field]; rule != nil {
+			value := valueOf(record, field)
+			invalid(field, value, rule.check(field, value))
+		}
 	}
 
-	// Validação de nome
-	name := strings.TrimSpace(record.Name)
-	if len(name) < 3 || len(name) > 100 {
-		errors = append(errors, fmt.Sprintf("nome deve ter entre 3 e 100 caracteres: %s", name))
+	// Validação estendida de email, se o formato já foi aceito pelas regras
+	if email := valueOf(record, "email"); v.email != nil && email.text != "" && !hasField(errs, "email") {
+		violations, err := v.email.check(ctx, email.text)
+		if err != nil {
+			return nil, err
+		}
+		invalid("email", email, violations)
 	}
 
-	// Validação de departamento
-	departments := map[string]bool{
-		"TI":            true,
-		"RH":            true,
-		"Financeiro":    true,
-		"Vendas":        true,
-		"Marketing":     true,
-		"Operações":     true,
-		"Jurídico":      true,
-		"Administração": true,
+	// Cadastro de departamentos
+	if department := valueOf(record, "department"); department.text != "" {
+		active, err := v.departments.IsActiveDepartment(department.text)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao validar departamento %q: %w", department.text, err)
+		}
+		if !active {
+			invalid("department", department, []violation{{code: models.CodeEnum, message: "departamento não cadastrado ou desativado"}})
+		}
 	}
 
-	department := strings.TrimSpace(record.Department)
-	if !departments[department] {
-		errors = append(errors, fmt.Sprintf("departamento inválido: %s", department))
+	// Regras entre campos
+	for _, condition := range v.rules.Conditions {
+		if !condition.applies(record) {
+			continue
+		}
+		for _, field := range fields {
+			if rule := condition.Then[field]; rule != nil {
+				value := valueOf(record, field)
+				violations := rule.check(field, value)
+				if condition.Name != "" && rule.Message == "" {
+					for i := range violations {
+						violations[i].message += " (regra: " + condition.Name + ")"
+					}
+				}
+				invalid(field, value, violations)
+			}
+		}
 	}
 
-	if len(errors) > 0 {
-		return &models.ValidationError{
-			RowNumber: record.RowNumber,
-			Field:     "validação",
-			Message:   strings.Join(errors, "; "),
-			Value:     record,
+	return warnings, errs.Err()
+}
+
+// hasField indica se já há erro no campo
+func hasField(errs models.ValidationErrors, field string) bool {
+	for _, err := range errs {
+		if err.Field == field {
+			return true
 		}
 	}
+	return false
+}
 
-	return nil
+// applies indica se o registro satisfaz todas as regras de When.
+// Um campo vazio não satisfaz a condição.
+func (c *Condition) applies(record *models.Record) bool {
+	for field, rule := range c.When {
+		value := valueOf(record, field)
+		if value.text == "" || len(rule.check(field, value)) > 0 {
+			return false
+		}
+	}
+	return true
 }
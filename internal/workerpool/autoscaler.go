@@ -0,0 +1,196 @@
+package workerpool
+
+import (
+	"sync"
+	"time"
+)
+
+// AutoscalerConfig configura o ajuste automático do número de workers
+type AutoscalerConfig struct {
+	// MinWorkers e MaxWorkers limitam o tamanho do pool
+	MinWorkers int
+	MaxWorkers int
+	// Interval é o período entre avaliações (padrão: 500ms)
+	Interval time.Duration
+	// BacklogPerWorker é quantas tarefas na fila, por worker, disparam o crescimento (padrão: 1)
+	BacklogPerWorker float64
+	// TargetLatency é a duração média de tarefa aceitável (zero = ignora latência).
+	// Acima dela, e sem fila acumulada, o pool encolhe um worker por vez, pois o
+	// gargalo está fora do pool. Com fila acumulada o pool cresce mesmo assim.
+	TargetLatency time.Duration
+	// ScaleUpCooldown e ScaleDownCooldown são os intervalos mínimos entre
+	// ajustes, evitando oscilação (padrão: 2s e 10s)
+	ScaleUpCooldown   time.Duration
+	ScaleDownCooldown time.Duration
+}
+
+// withDefaults preenche os campos não definidos da configuração
+func (c AutoscalerConfig) withDefaults() AutoscalerConfig {
+	if c.MinWorkers <= 0 {
+		c.MinWorkers = 1
+	}
+	if c.MaxWorkers < c.MinWorkers {
+		c.MaxWorkers = c.MinWorkers
+	}
+	if c.Interval <= 0 {
+		c.Interval = 500 * time.Millisecond
+	}
+	if c.BacklogPerWorker <= 0 {
+		c.BacklogPerWorker = 1
+	}
+	if c.ScaleUpCooldown <= 0 {
+		c.ScaleUpCooldown = 2 * time.Second
+	}
+	if c.ScaleDownCooldown <= 0 {
+		c.ScaleDownCooldown = 10 * time.Second
+	}
+	return c
+}
+
+// Autoscaler ajusta periodicamente o número de workers de um Pool com base
+// no tamanho da fila e na latência das tarefas
+type Autoscaler[In, Out any] struct {
+	pool   *Pool[In, Out]
+	config AutoscalerConfig
+
+	lastScale  time.Time
+	lastSample latencySample
+
+	// started e stopped são protegidos por mu; done só é fechado se run foi iniciado
+	mu      sync.Mutex
+	started bool
+	stopped bool
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// NewAutoscaler cria um autoscaler para o pool. O pool é ajustado para ficar
+// dentro dos limites de MinWorkers e MaxWorkers quando o autoscaler é iniciado.
+func NewAutoscaler[In, Out any](pool *Pool[In, Out], config AutoscalerConfig) *Autoscaler[In, Out] {
+	return &Autoscaler[In, Out]{
+		pool:   pool,
+		config: config.withDefaults(),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+}
+
+// Start inicia a avaliação periódica em segundo plano.
+// Chamadas repetidas, ou após Stop, não têm efeito.
+func (a *Autoscaler[In, Out]) Start() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.started || a.stopped {
+		return
+	}
+	a.started = true
+
+	workers := a.pool.targetWorkers()
+	if clamped := a.clamp(workers); clamped != workers {
+		a.pool.Resize(clamped)
+	}
+	a.lastSample = a.sample()
+
+	go a.run()
+}
+
+// Stop encerra o autoscaler, mantendo o número atual de workers.
+// Pode ser chamado mais de uma vez, mesmo que Start não tenha sido chamado.
+func (a *Autoscaler[In, Out]) Stop() {
+	a.mu.Lock()
+	started := a.started
+	if !a.stopped {
+		a.stopped = true
+		close(a.stop)
+	}
+	a.mu.Unlock()
+
+	if started {
+		<-a.done
+	}
+}
+
+// run avalia o pool a cada intervalo até Stop ser chamado
+func (a *Autoscaler[In, Out]) run() {
+	defer close(a.done)
+
+	ticker := time.NewTicker(a.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			a.evaluate(now)
+		case <-a.stop:
+			return
+		}
+	}
+}
+
+// evaluate coleta a fila e a latência desde a última avaliação e aplica o novo tamanho
+func (a *Autoscaler[In, Out]) evaluate(now time.Time) {
+	current := a.sample()
+	latency := current.latencySince(a.lastSample)
+	a.lastSample = current
+
+	// O tamanho desejado, e não o atual: workers aposentados ainda podem estar terminando
+	workers := a.pool.targetWorkers()
+	target := a.decide(workers, a.pool.QueueDepth(), latency, now)
+	if target != workers {
+		a.pool.Resize(target)
+		a.lastScale = now
+	}
+}
+
+// decide calcula o número de workers desejado, respeitando limites e cooldowns
+func (a *Autoscaler[In, Out]) decide(workers, backlog int, latency time.Duration, now time.Time) int {
+	cfg := a.config
+	sinceLast := now.Sub(a.lastScale)
+	overLatency := cfg.TargetLatency > 0 && latency > cfg.TargetLatency
+
+	switch {
+	case float64(backlog) >= float64(workers)*cfg.BacklogPerWorker:
+		// A fila acumulada tem prioridade sobre a latência: encolher com tarefas
+		// lentas só faria a fila crescer
+		if sinceLast >= cfg.ScaleUpCooldown {
+			// Cresce 50% por vez (ao menos um worker)
+			return a.clamp(workers + max(1, workers/2))
+		}
+
+	case overLatency, backlog == 0:
+		// Fila vazia, ou tarefas lentas sem fila acumulada: com o gargalo fora do
+		// pool, mais workers só aumentariam a disputa pelo recurso lento
+		if sinceLast >= cfg.ScaleDownCooldown {
+			return a.clamp(workers - 1)
+		}
+	}
+
+	return a.clamp(workers)
+}
+
+// clamp limita n aos valores mínimo e máximo de workers
+func (a *Autoscaler[In, Out]) clamp(n int) int {
+	return min(max(n, a.config.MinWorkers), a.config.MaxWorkers)
+}
+
+// latencySample é uma leitura dos totais de tarefas usada para medir a latência recente
+type latencySample struct {
+	tasks    int64
+	duration time.Duration
+}
+
+// sample lê os totais atuais do pool
+func (a *Autoscaler[In, Out]) sample() latencySample {
+	metrics := a.pool.GetMetrics()
+	return latencySample{tasks: metrics.TasksProcessed, duration: metrics.TotalDuration}
+}
+
+// latencySince calcula a duração média das tarefas concluídas desde a leitura anterior
+func (s latencySample) latencySince(previous latencySample) time.Duration {
+	tasks := s.tasks - previous.tasks
+	if tasks <= 0 {
+		return 0
+	}
+	return (s.duration - previous.duration) / time.Duration(tasks)
+}
@@ -0,0 +1,152 @@
+package workerpool
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAutoscalerConfig_Defaults(t *testing.T) {
+	cfg := AutoscalerConfig{MinWorkers: 4, MaxWorkers: 2}.withDefaults()
+
+	if cfg.MaxWorkers != 4 {
+		t.Errorf("Expected MaxWorkers raised to MinWorkers (4), got %d", cfg.MaxWorkers)
+	}
+	if cfg.Interval <= 0 || cfg.ScaleUpCooldown <= 0 || cfg.ScaleDownCooldown <= 0 {
+		t.Errorf("Expected positive intervals, got %+v", cfg)
+	}
+}
+
+func TestAutoscaler_Decide(t *testing.T) {
+	pool := NewPool[int, int](4, 10)
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		workers   int
+		backlog   int
+		latency   time.Duration
+		lastScale time.Time
+		expected  int
+	}{
+		{"Backlog grows pool", 4, 10, 0, time.Time{}, 6},
+		{"Growth capped at max", 7, 50, 0, time.Time{}, 8},
+		{"Growth respects cooldown", 4, 10, 0, now.Add(-time.Second), 4},
+		{"Empty queue shrinks pool", 4, 0, 0, time.Time{}, 3},
+		{"Shrink capped at min", 2, 0, 0, time.Time{}, 2},
+		{"Shrink respects cooldown", 4, 0, 0, now.Add(-5 * time.Second), 4},
+		{"High latency shrinks without backlog", 4, 2, time.Second, time.Time{}, 3},
+		{"High latency shrink respects cooldown", 4, 2, time.Second, now.Add(-5 * time.Second), 4},
+		{"High latency with backlog still grows", 4, 10, time.Second, time.Time{}, 6},
+		{"High latency with large backlog grows", 6, 100000, time.Second, time.Time{}, 8},
+		{"Steady state", 4, 2, 0, time.Time{}, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewAutoscaler(pool, AutoscalerConfig{
+				MinWorkers:        2,
+				MaxWorkers:        8,
+				TargetLatency:     100 * time.Millisecond,
+				ScaleUpCooldown:   2 * time.Second,
+				ScaleDownCooldown: 10 * time.Second,
+			})
+			a.lastScale = tt.lastScale
+
+			if got := a.decide(tt.workers, tt.backlog, tt.latency, now); got != tt.expected {
+				t.Errorf("Expected %d workers, got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestLatencySample(t *testing.T) {
+	previous := latencySample{tasks: 10, duration: time.Second}
+	current := latencySample{tasks: 20, duration: 3 * time.Second}
+
+	if got := current.latencySince(previous); got != 200*time.Millisecond {
+		t.Errorf("Expected 200ms, got %v", got)
+	}
+	if got := previous.latencySince(previous); got != 0 {
+		t.Errorf("Expected 0 with no new tasks, got %v", got)
+	}
+}
+
+func TestAutoscaler_ScalesWithBacklog(t *testing.T) {
+	pool := NewWorkerPool(1, 100)
+	pool.Start()
+	defer pool.Stop()
+
+	a := NewAutoscaler(pool.Pool, AutoscalerConfig{
+		MinWorkers:        1,
+		MaxWorkers:        6,
+		Interval:          5 * time.Millisecond,
+		ScaleUpCooldown:   time.Millisecond,
+		ScaleDownCooldown: 20 * time.Millisecond,
+	})
+	a.Start()
+	defer a.Stop()
+
+	for i := 0; i < 60; i++ {
+		pool.Submit(Task{
+			ID: i,
+			Handler: func(payload interface{}) (interface{}, error) {
+				time.Sleep(5 * time.Millisecond)
+				return nil, nil
+			},
+		})
+	}
+
+	deadline := time.After(2 * time.Second)
+	for pool.GetWorkerCount() < 6 {
+		select {
+		case <-deadline:
+			t.Fatalf("Expected pool to grow to 6 workers, got %d", pool.GetWorkerCount())
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+
+	// Com a fila vazia, o pool volta ao mínimo
+	deadline = time.After(3 * time.Second)
+	for pool.GetWorkerCount() > 1 {
+		select {
+		case <-deadline:
+			t.Fatalf("Expected pool to shrink to 1 worker, got %d", pool.GetWorkerCount())
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+}
+
+func TestAutoscaler_StartClampsPool(t *testing.T) {
+	pool := NewPool[int, int](20, 10)
+	a := NewAutoscaler(pool, AutoscalerConfig{MinWorkers: 1, MaxWorkers: 4})
+	a.Start()
+	a.Stop()
+
+	if pool.GetWorkerCount() != 4 {
+		t.Errorf("Expected pool clamped to 4 workers, got %d", pool.GetWorkerCount())
+	}
+}
+
+func TestAutoscaler_StopWithoutStart(t *testing.T) {
+	pool := NewPool[int, int](2, 10)
+	a := NewAutoscaler(pool, AutoscalerConfig{MinWorkers: 1, MaxWorkers: 4})
+
+	stopped := make(chan struct{})
+	go func() {
+		a.Stop()
+		a.Stop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("Stop without Start did not return")
+	}
+
+	// Após Stop, Start não tem efeito
+	a.Start()
+	if pool.GetWorkerCount() != 2 {
+		t.Errorf("Expected 2 workers after Start on a stopped autoscaler, got %d", pool.GetWorkerCount())
+	}
+}
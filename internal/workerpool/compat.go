@@ -0,0 +1,30 @@
+package workerpool
+
+// HandlerFunc é o handler não tipado com contexto
+type HandlerFunc = TypedHandler[interface{}, interface{}]
+
+// Task representa uma tarefa não tipada a ser processada
+type Task = TypedTask[interface{}, interface{}]
+
+// Result representa o resultado de uma tarefa não tipada
+type Result = TypedResult[interface{}, interface{}]
+
+// WorkerPool é um Pool não tipado, mantido por compatibilidade.
+// Novos usos devem preferir NewPool com os tipos concretos de entrada e saída.
+type WorkerPool struct {
+	*Pool[interface{}, interface{}]
+}
+
+// NewWorkerPool cria uma nova instância do WorkerPool
+func NewWorkerPool(workerCount int, queueSize int) *WorkerPool {
+	return &WorkerPool{
+		Pool: NewPool[interface{}, interface{}](workerCount, queueSize),
+	}
+}
+
+// NewWorkerPoolWithOptions cria uma nova instância do WorkerPool a partir de Options
+func NewWorkerPoolWithOptions(opts Options) *WorkerPool {
+	return &WorkerPool{
+		Pool: NewPoolWithOptions[interface{}, interface{}](opts),
+	}
+}
@@ -1,9 +1,33 @@
 package workerpool
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
-	ErrPoolNotStarted = errors.New("worker pool não foi iniciado")
-	ErrPoolStopped    = errors.New("worker pool foi parado")
-	ErrQueueFull      = errors.New("fila de tarefas está cheia")
+	ErrPoolNotStarted     = errors.New("worker pool não foi iniciado")
+	ErrPoolStopped        = errors.New("worker pool foi parado")
+	ErrPoolAlreadyStarted = errors.New("worker pool já foi iniciado")
+	ErrQueueFull          = errors.New("fila de tarefas está cheia")
+	ErrNoHandler          = errors.New("tarefa sem handler definido")
+	ErrSubmitTimeout      = errors.New("tempo esgotado aguardando espaço na fila")
+	ErrTaskTimeout        = errors.New("tempo limite da tarefa excedido")
+	ErrTaskPanicked       = errors.New("handler da tarefa entrou em panic")
 )
+
+// PanicError é retornado quando o handler de uma tarefa entra em panic.
+// errors.Is(err, ErrTaskPanicked) identifica esse caso.
+type PanicError struct {
+	TaskID int
+	Value  interface{}
+	Stack  []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("%v (tarefa #%d): %v", ErrTaskPanicked, e.TaskID, e.Value)
+}
+
+func (e *PanicError) Unwrap() error {
+	return ErrTaskPanicked
+}
@@ -0,0 +1,145 @@
+package workerpool
+
+import (
+	"context"
+	"runtime/debug"
+	"time"
+)
+
+// Middleware envolve um handler, adicionando comportamento antes e depois da execução.
+// É aplicado a cada tentativa da tarefa, dentro da recuperação de panic.
+type Middleware[In, Out any] func(next TypedHandler[In, Out]) TypedHandler[In, Out]
+
+// TaskEvent descreve a execução de uma tarefa para os hooks
+type TaskEvent[In, Out any] struct {
+	Task     TypedTask[In, Out]
+	WorkerID int
+	// Output, Err, Attempts e Duration são preenchidos após a execução
+	Output   Out
+	Err      error
+	Attempts int
+	Duration time.Duration
+}
+
+// Hooks são callbacks chamados em pontos do ciclo de vida do pool.
+// Campos nil são ignorados. Os hooks rodam na goroutine do worker (ou de quem
+// submeteu, no caso de OnSubmit) e devem ser rápidos. Um panic em um hook é
+// recuperado e registrado no log como *PanicError, sem derrubar o worker.
+type Hooks[In, Out any] struct {
+	// OnSubmit é chamado uma vez para cada tarefa aceita na fila, fora de
+	// qualquer lock do pool; submissões rejeitadas (ErrQueueFull,
+	// ErrPoolStopped...) não o chamam. A execução da tarefa aguarda o hook,
+	// então ele precede BeforeExecute, exceto se o pool estiver parando.
+	OnSubmit      func(task TypedTask[In, Out])
+	BeforeExecute func(ctx context.Context, event TaskEvent[In, Out])
+	AfterExecute  func(ctx context.Context, event TaskEvent[In, Out])
+	OnError       func(ctx context.Context, event TaskEvent[In, Out])
+	OnWorkerStart func(workerID int)
+	OnWorkerStop  func(workerID int)
+}
+
+// Use adiciona middlewares ao pool. O primeiro middleware registrado é o mais
+// externo. Deve ser chamado antes de Start.
+func (wp *Pool[In, Out]) Use(middleware ...Middleware[In, Out]) error {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+
+	if wp.started || wp.queueClosed {
+		return ErrPoolAlreadyStarted
+	}
+	wp.middleware = append(wp.middleware, middleware...)
+	return nil
+}
+
+// AddHooks registra hooks no pool. Hooks registrados são chamados na ordem de
+// registro. Deve ser chamado antes de Start.
+func (wp *Pool[In, Out]) AddHooks(hooks Hooks[In, Out]) error {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+
+	if wp.started || wp.queueClosed {
+		return ErrPoolAlreadyStarted
+	}
+	wp.hooks = append(wp.hooks, hooks)
+	return nil
+}
+
+// wrap aplica a cadeia de middlewares ao handler
+func (wp *Pool[In, Out]) wrap(handler TypedHandler[In, Out]) TypedHandler[In, Out] {
+	for i := len(wp.middleware) - 1; i >= 0; i-- {
+		handler = wp.middleware[i](handler)
+	}
+	return handler
+}
+
+// callHook executa um hook convertendo um panic em *PanicError, que é apenas
+// registrado no log: os demais hooks e a tarefa seguem normalmente.
+// taskID é zero nos hooks de worker.
+func (wp *Pool[In, Out]) callHook(name string, taskID int, hook func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			err := &PanicError{TaskID: taskID, Value: r, Stack: debug.Stack()}
+			wp.logger.Error("panic no hook", "hook", name, "task_id", taskID, "error", err, "stack", string(err.Stack))
+		}
+	}()
+	hook()
+}
+
+// hasOnSubmit indica se algum hook OnSubmit foi registrado
+func (wp *Pool[In, Out]) hasOnSubmit() bool {
+	for _, h := range wp.hooks {
+		if h.OnSubmit != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (wp *Pool[In, Out]) onSubmit(task TypedTask[In, Out]) {
+	for _, h := range wp.hooks {
+		if h.OnSubmit != nil {
+			wp.callHook("OnSubmit", task.ID, func() { h.OnSubmit(task) })
+		}
+	}
+}
+
+func (wp *Pool[In, Out]) beforeExecute(ctx context.Context, event TaskEvent[In, Out]) {
+	for _, h := range wp.hooks {
+		if h.BeforeExecute != nil {
+			wp.callHook("BeforeExecute", event.Task.ID, func() { h.BeforeExecute(ctx, event) })
+		}
+	}
+}
+
+// afterExecute chama AfterExecute e, se a tarefa falhou, OnError
+func (wp *Pool[In, Out]) afterExecute(ctx context.Context, event TaskEvent[In, Out]) {
+	for _, h := range wp.hooks {
+		if h.AfterExecute != nil {
+			wp.callHook("AfterExecute", event.Task.ID, func() { h.AfterExecute(ctx, event) })
+		}
+	}
+	if event.Err == nil {
+		return
+	}
+	for _, h := range wp.hooks {
+		if h.OnError != nil {
+			wp.callHook("OnError", event.Task.ID, func() { h.OnError(ctx, event) })
+		}
+	}
+}
+
+func (wp *Pool[In, Out]) onWorkerStart(workerID int) {
+	for _, h := range wp.hooks {
+		if h.OnWorkerStart != nil {
+			wp.callHook("OnWorkerStart", 0, func() { h.OnWorkerStart(workerID) })
+		}
+	}
+}
+
+func (wp *Pool[In, Out]) onWorkerStop(workerID int) {
+	for _, h := range wp.hooks {
+		if h.OnWorkerStop != nil {
+			wp.callHook("OnWorkerStop", 0, func() { h.OnWorkerStop(workerID) })
+		}
+	}
+}
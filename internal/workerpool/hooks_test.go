@@ -0,0 +1,331 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPool_MiddlewareOrder(t *testing.T) {
+	pool := NewPool[string, string](1, 10)
+
+	var mu sync.Mutex
+	var calls []string
+	record := func(name string) Middleware[string, string] {
+		return func(next TypedHandler[string, string]) TypedHandler[string, string] {
+			return func(ctx context.Context, payload string) (string, error) {
+				mu.Lock()
+				calls = append(calls, name+":before")
+				mu.Unlock()
+				out, err := next(ctx, payload)
+				mu.Lock()
+				calls = append(calls, name+":after")
+				mu.Unlock()
+				return name + "(" + out + ")", err
+			}
+		}
+	}
+
+	if err := pool.Use(record("outer"), record("inner")); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	pool.Start()
+	defer pool.Stop()
+
+	task := TypedTask[string, string]{
+		ID:      1,
+		Payload: "x",
+		Handler: func(payload string) (string, error) {
+			return payload, nil
+		},
+		Result: make(chan TypedResult[string, string], 1),
+	}
+	pool.Submit(task)
+
+	select {
+	case result := <-task.Result:
+		if result.Output != "outer(inner(x))" {
+			t.Errorf("Expected 'outer(inner(x))', got %q", result.Output)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timeout waiting for result")
+	}
+
+	expected := []string{"outer:before", "inner:before", "inner:after", "outer:after"}
+	mu.Lock()
+	defer mu.Unlock()
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("Expected calls %v, got %v", expected, calls)
+	}
+}
+
+func TestPool_Hooks(t *testing.T) {
+	pool := NewPool[int, int](1, 10)
+
+	var mu sync.Mutex
+	var events []string
+	workerStarted := make(chan struct{})
+	add := func(event string) {
+		mu.Lock()
+		events = append(events, event)
+		mu.Unlock()
+	}
+
+	err := pool.AddHooks(Hooks[int, int]{
+		OnSubmit:      func(task TypedTask[int, int]) { add("submit") },
+		BeforeExecute: func(ctx context.Context, e TaskEvent[int, int]) { add("before") },
+		AfterExecute:  func(ctx context.Context, e TaskEvent[int, int]) { add("after") },
+		OnError:       func(ctx context.Context, e TaskEvent[int, int]) { add("error:" + e.Err.Error()) },
+		OnWorkerStart: func(workerID int) { add("worker:start"); close(workerStarted) },
+		OnWorkerStop:  func(workerID int) { add("worker:stop") },
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	// Um segundo conjunto de hooks é chamado depois do primeiro
+	pool.AddHooks(Hooks[int, int]{
+		AfterExecute: func(ctx context.Context, e TaskEvent[int, int]) { add("after:2") },
+	})
+
+	pool.Start()
+	<-workerStarted
+
+	errChan := make(chan error, 1)
+	pool.Submit(TypedTask[int, int]{
+		ID: 1,
+		Handler: func(n int) (int, error) {
+			return 0, errors.New("boom")
+		},
+		Error: errChan,
+	})
+
+	select {
+	case <-errChan:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timeout waiting for error")
+	}
+	pool.Stop()
+
+	expected := []string{"worker:start", "submit", "before", "after", "after:2", "error:boom", "worker:stop"}
+	mu.Lock()
+	defer mu.Unlock()
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("Expected events %v, got %v", expected, events)
+	}
+}
+
+func TestPool_HooksAfterStart(t *testing.T) {
+	pool := NewPool[int, int](1, 10)
+	pool.Start()
+	defer pool.Stop()
+
+	if err := pool.Use(); err != ErrPoolAlreadyStarted {
+		t.Errorf("Expected ErrPoolAlreadyStarted from Use, got %v", err)
+	}
+	if err := pool.AddHooks(Hooks[int, int]{}); err != ErrPoolAlreadyStarted {
+		t.Errorf("Expected ErrPoolAlreadyStarted from AddHooks, got %v", err)
+	}
+}
+
+func TestPool_MiddlewarePanicRecovered(t *testing.T) {
+	pool := NewPool[int, int](1, 10)
+	pool.Use(func(next TypedHandler[int, int]) TypedHandler[int, int] {
+		return func(ctx context.Context, n int) (int, error) {
+			panic("middleware panic")
+		}
+	})
+	pool.Start()
+	defer pool.Stop()
+
+	errChan := make(chan error, 1)
+	pool.Submit(TypedTask[int, int]{
+		ID:      1,
+		Handler: func(n int) (int, error) { return n, nil },
+		Error:   errChan,
+	})
+
+	select {
+	case err := <-errChan:
+		if !errors.Is(err, ErrTaskPanicked) {
+			t.Errorf("Expected ErrTaskPanicked, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timeout waiting for error")
+	}
+}
+
+func TestPool_OnSubmitPrecedesExecution(t *testing.T) {
+	pool := NewPool[int, int](4, 50)
+
+	var mu sync.Mutex
+	submitted := make(map[int]bool)
+	var outOfOrder []int
+	pool.AddHooks(Hooks[int, int]{
+		OnSubmit: func(task TypedTask[int, int]) {
+			// Um hook lento não pode ser ultrapassado pelo worker
+			time.Sleep(time.Millisecond)
+			mu.Lock()
+			submitted[task.ID] = true
+			mu.Unlock()
+		},
+		BeforeExecute: func(ctx context.Context, event TaskEvent[int, int]) {
+			mu.Lock()
+			if !submitted[event.Task.ID] {
+				outOfOrder = append(outOfOrder, event.Task.ID)
+			}
+			mu.Unlock()
+		},
+	})
+	pool.Start()
+
+	for i := 0; i < 50; i++ {
+		pool.Submit(TypedTask[int, int]{
+			ID:      i,
+			Handler: func(payload int) (int, error) { return payload, nil },
+		})
+	}
+	pool.Shutdown(context.Background())
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(outOfOrder) > 0 {
+		t.Errorf("Expected OnSubmit before BeforeExecute, tasks out of order: %v", outOfOrder)
+	}
+}
+
+func TestPool_OnSubmitCanStopPool(t *testing.T) {
+	pool := NewPool[int, int](1, 10)
+	pool.AddHooks(Hooks[int, int]{
+		OnSubmit: func(task TypedTask[int, int]) {
+			pool.Stop()
+		},
+	})
+	pool.Start()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- pool.Submit(TypedTask[int, int]{ID: 1})
+	}()
+
+	// A tarefa já tinha sido aceita quando o hook parou o pool
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Expected nil, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Deadlock: Stop inside OnSubmit did not return")
+	}
+}
+
+func TestPool_OnSubmitOnlyForAcceptedTasks(t *testing.T) {
+	pool := NewPool[int, int](1, 1)
+
+	var mu sync.Mutex
+	var submitted []int
+	pool.AddHooks(Hooks[int, int]{
+		OnSubmit: func(task TypedTask[int, int]) {
+			mu.Lock()
+			submitted = append(submitted, task.ID)
+			mu.Unlock()
+		},
+	})
+
+	// Antes de Start a submissão é rejeitada
+	if err := pool.Submit(TypedTask[int, int]{ID: 0}); err != ErrPoolNotStarted {
+		t.Fatalf("Expected ErrPoolNotStarted, got %v", err)
+	}
+	pool.Start()
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	blocking := func(n int) (int, error) {
+		if n == 1 {
+			close(started)
+		}
+		<-release
+		return n, nil
+	}
+	pool.Submit(TypedTask[int, int]{ID: 1, Payload: 1, Handler: blocking})
+	<-started
+
+	// Com o worker ocupado, enche o dispatcher e a fila
+	accepted := []int{1}
+	for id := 2; ; id++ {
+		if err := pool.Submit(TypedTask[int, int]{ID: id, Payload: id, Handler: blocking}); err != nil {
+			break
+		}
+		accepted = append(accepted, id)
+	}
+
+	// As novas tentativas são rejeitadas e não chamam o hook
+	for i := 0; i < 3; i++ {
+		if err := pool.Submit(TypedTask[int, int]{ID: 99, Payload: 99, Handler: blocking}); err != ErrQueueFull {
+			t.Errorf("Expected ErrQueueFull, got %v", err)
+		}
+	}
+	close(release)
+	pool.Shutdown(context.Background())
+
+	if err := pool.Submit(TypedTask[int, int]{ID: 100}); err == nil {
+		t.Error("Expected an error after Shutdown, got nil")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if !reflect.DeepEqual(submitted, accepted) {
+		t.Errorf("Expected OnSubmit only for accepted tasks %v, got %v", accepted, submitted)
+	}
+}
+
+func TestPool_HookPanicIsRecovered(t *testing.T) {
+	pool := NewPool[int, int](1, 10)
+
+	var mu sync.Mutex
+	var after []int
+	pool.AddHooks(Hooks[int, int]{
+		OnSubmit:      func(task TypedTask[int, int]) { panic("submit") },
+		BeforeExecute: func(ctx context.Context, e TaskEvent[int, int]) { panic("before") },
+		AfterExecute:  func(ctx context.Context, e TaskEvent[int, int]) { panic("after") },
+		OnWorkerStart: func(workerID int) { panic("start") },
+		OnWorkerStop:  func(workerID int) { panic("stop") },
+	})
+	// Os hooks seguintes ainda são chamados
+	pool.AddHooks(Hooks[int, int]{
+		AfterExecute: func(ctx context.Context, e TaskEvent[int, int]) {
+			mu.Lock()
+			after = append(after, e.Task.ID)
+			mu.Unlock()
+		},
+	})
+	pool.Start()
+
+	result := make(chan TypedResult[int, int], 1)
+	if err := pool.Submit(TypedTask[int, int]{
+		ID:      1,
+		Payload: 21,
+		Handler: func(n int) (int, error) { return n * 2, nil },
+		Result:  result,
+	}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	select {
+	case r := <-result:
+		if r.Output != 42 {
+			t.Errorf("Expected 42, got %d", r.Output)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timeout waiting for result")
+	}
+	pool.Stop()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if !reflect.DeepEqual(after, []int{1}) {
+		t.Errorf("Expected the second AfterExecute hook to run, got %v", after)
+	}
+}
@@ -0,0 +1,295 @@
+package workerpool
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Metrics é uma fotografia das métricas do worker pool, obtida com GetMetrics
+type Metrics struct {
+	TasksProcessed  int64
+	TasksFailed     int64
+	TasksRetried    int64 // tarefas que precisaram de mais de uma tentativa
+	TotalRetries    int64 // tentativas extras somadas de todas as tarefas
+	TasksTimedOut   int64 // tarefas interrompidas pelo prazo (também contam como falhas)
+	TasksPanicked   int64 // tarefas cujo handler entrou em panic (também contam como falhas)
+	TasksInFlight   int64 // tarefas em execução no momento da leitura
+	TotalDuration   time.Duration
+	AverageDuration time.Duration
+	// Latency é a distribuição do tempo de execução das tarefas (sem a espera na fila)
+	Latency LatencySummary
+	// QueueWait é a distribuição do tempo entre o envio e a entrega a um worker
+	QueueWait LatencySummary
+	// Workers traz as métricas de cada worker, ordenadas por ID.
+	// Workers aposentados por Resize deixam a lista, mas continuam nos totais.
+	Workers []WorkerMetrics
+}
+
+// LatencySummary resume um histograma de durações
+type LatencySummary struct {
+	Count int64
+	Sum   time.Duration
+	Max   time.Duration
+	// P50, P95 e P99 são estimados por interpolação dentro do bucket
+	P50 time.Duration
+	P95 time.Duration
+	P99 time.Duration
+	// Buckets traz as contagens acumuladas por limite superior; o último bucket
+	// tem UpperBound igual a math.MaxInt64 e contém todas as observações
+	Buckets []HistogramBucket
+}
+
+// HistogramBucket é um bucket acumulado do histograma
+type HistogramBucket struct {
+	UpperBound time.Duration
+	Count      int64
+}
+
+// Quantile estima o quantil q (entre 0 e 1) a partir dos buckets
+func (s LatencySummary) Quantile(q float64) time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	q = math.Min(math.Max(q, 0), 1)
+	rank := q * float64(s.Count)
+
+	var lower time.Duration
+	var previous int64
+	for _, bucket := range s.Buckets {
+		if float64(bucket.Count) >= rank && bucket.Count > previous {
+			upper := bucket.UpperBound
+			if upper > s.Max {
+				upper = s.Max
+			}
+			fraction := (rank - float64(previous)) / float64(bucket.Count-previous)
+			return lower + time.Duration(fraction*float64(upper-lower))
+		}
+		lower, previous = bucket.UpperBound, bucket.Count
+	}
+	return s.Max
+}
+
+// WorkerMetrics descreve a atividade de um worker
+type WorkerMetrics struct {
+	ID             int
+	TasksProcessed int64
+	// BusyTime é o tempo gasto executando tarefas, incluindo a tarefa atual
+	BusyTime time.Duration
+	// Uptime é o tempo desde o início do worker até agora (ou até sua parada)
+	Uptime time.Duration
+	// Utilization é a fração do Uptime em que o worker esteve ocupado (0 a 1)
+	Utilization float64
+	Busy        bool
+}
+
+// histogramBuckets é o número de buckets finitos: de 100µs a ~105s, dobrando a cada bucket
+const histogramBuckets = 21
+
+// histogramBase é o limite superior do primeiro bucket
+const histogramBase = 100 * time.Microsecond
+
+// bucketBound retorna o limite superior do bucket i
+func bucketBound(i int) time.Duration {
+	if i >= histogramBuckets {
+		return math.MaxInt64
+	}
+	return histogramBase << i
+}
+
+// histogram é um histograma de durações atualizado sem locks
+type histogram struct {
+	// counts tem um bucket extra para valores acima do último limite
+	counts [histogramBuckets + 1]atomic.Int64
+	sum    atomic.Int64
+	max    atomic.Int64
+}
+
+// observe registra uma duração
+func (h *histogram) observe(d time.Duration) {
+	if d < 0 {
+		d = 0
+	}
+	i := sort.Search(histogramBuckets, func(i int) bool { return d <= bucketBound(i) })
+	h.counts[i].Add(1)
+	h.sum.Add(int64(d))
+
+	for {
+		current := h.max.Load()
+		if int64(d) <= current || h.max.CompareAndSwap(current, int64(d)) {
+			return
+		}
+	}
+}
+
+// summary lê o histograma. Leituras concorrentes com observe podem ficar
+// levemente defasadas entre si, mas nunca bloqueiam os workers.
+func (h *histogram) summary() LatencySummary {
+	s := LatencySummary{
+		Sum:     time.Duration(h.sum.Load()),
+		Max:     time.Duration(h.max.Load()),
+		Buckets: make([]HistogramBucket, len(h.counts)),
+	}
+	for i := range h.counts {
+		s.Count += h.counts[i].Load()
+		s.Buckets[i] = HistogramBucket{UpperBound: bucketBound(i), Count: s.Count}
+	}
+	s.P50 = s.Quantile(0.50)
+	s.P95 = s.Quantile(0.95)
+	s.P99 = s.Quantile(0.99)
+	return s
+}
+
+// workerStats acumula a atividade de um worker. Só o próprio worker escreve;
+// os campos são atômicos para que GetMetrics possa lê-los a qualquer momento.
+type workerStats struct {
+	id        int
+	startedAt time.Time
+	stoppedAt atomic.Int64 // UnixNano da parada (0 = ativo)
+	busySince atomic.Int64 // UnixNano do início da tarefa atual (0 = ocioso)
+	busy      atomic.Int64 // tempo ocupado acumulado, em nanossegundos
+	tasks     atomic.Int64
+}
+
+// snapshot calcula as métricas do worker no instante now
+func (w *workerStats) snapshot(now time.Time) WorkerMetrics {
+	end := now
+	if stopped := w.stoppedAt.Load(); stopped != 0 {
+		end = time.Unix(0, stopped)
+	}
+
+	m := WorkerMetrics{
+		ID:             w.id,
+		TasksProcessed: w.tasks.Load(),
+		BusyTime:       time.Duration(w.busy.Load()),
+		Uptime:         end.Sub(w.startedAt),
+	}
+	if since := w.busySince.Load(); since != 0 {
+		m.Busy = true
+		m.BusyTime += now.Sub(time.Unix(0, since))
+	}
+	if m.Uptime > 0 {
+		m.Utilization = math.Min(float64(m.BusyTime)/float64(m.Uptime), 1)
+	}
+	return m
+}
+
+// metricsCollector coleta as métricas do pool. Os contadores são atômicos,
+// então registrar uma tarefa não serializa os workers.
+type metricsCollector struct {
+	tasksProcessed atomic.Int64
+	tasksFailed    atomic.Int64
+	tasksRetried   atomic.Int64
+	totalRetries   atomic.Int64
+	tasksTimedOut  atomic.Int64
+	tasksPanicked  atomic.Int64
+	inFlight       atomic.Int64
+
+	latency   histogram
+	queueWait histogram
+
+	// workersMu protege apenas o registro de workers, alterado quando um worker
+	// inicia ou é aposentado
+	workersMu sync.Mutex
+	workers   map[int]*workerStats
+}
+
+// newMetricsCollector cria um coletor vazio
+func newMetricsCollector() *metricsCollector {
+	return &metricsCollector{workers: make(map[int]*workerStats)}
+}
+
+// registerWorker começa a acompanhar um worker
+func (m *metricsCollector) registerWorker(id int) *workerStats {
+	ws := &workerStats{id: id, startedAt: time.Now()}
+
+	m.workersMu.Lock()
+	defer m.workersMu.Unlock()
+	m.workers[id] = ws
+	return ws
+}
+
+// stopWorker registra a parada do worker. Workers aposentados por Resize deixam
+// de ser listados; os demais continuam visíveis depois que o pool para.
+func (m *metricsCollector) stopWorker(ws *workerStats, retired bool) {
+	ws.stoppedAt.Store(time.Now().UnixNano())
+	if !retired {
+		return
+	}
+
+	m.workersMu.Lock()
+	defer m.workersMu.Unlock()
+	delete(m.workers, ws.id)
+}
+
+// taskStarted marca o worker como ocupado, registrando a espera da tarefa na fila
+func (m *metricsCollector) taskStarted(ws *workerStats, enqueuedAt time.Time) {
+	now := time.Now()
+	m.queueWait.observe(now.Sub(enqueuedAt))
+	m.inFlight.Add(1)
+	ws.busySince.Store(now.UnixNano())
+}
+
+// taskFinished marca o worker como ocioso
+func (m *metricsCollector) taskFinished(ws *workerStats) {
+	since := ws.busySince.Swap(0)
+	ws.busy.Add(time.Now().UnixNano() - since)
+	ws.tasks.Add(1)
+	m.inFlight.Add(-1)
+}
+
+// record registra o resultado de uma tarefa
+func (m *metricsCollector) record(err error, duration time.Duration, attempts int) {
+	m.tasksProcessed.Add(1)
+	m.latency.observe(duration)
+	if attempts > 1 {
+		m.tasksRetried.Add(1)
+		m.totalRetries.Add(int64(attempts - 1))
+	}
+
+	if err != nil {
+		m.tasksFailed.Add(1)
+	}
+	if errors.Is(err, ErrTaskTimeout) {
+		m.tasksTimedOut.Add(1)
+	}
+	if errors.Is(err, ErrTaskPanicked) {
+		m.tasksPanicked.Add(1)
+	}
+}
+
+// snapshot monta a fotografia das métricas
+func (m *metricsCollector) snapshot() Metrics {
+	now := time.Now()
+	latency := m.latency.summary()
+
+	metrics := Metrics{
+		TasksProcessed: m.tasksProcessed.Load(),
+		TasksFailed:    m.tasksFailed.Load(),
+		TasksRetried:   m.tasksRetried.Load(),
+		TotalRetries:   m.totalRetries.Load(),
+		TasksTimedOut:  m.tasksTimedOut.Load(),
+		TasksPanicked:  m.tasksPanicked.Load(),
+		TasksInFlight:  m.inFlight.Load(),
+		TotalDuration:  latency.Sum,
+		Latency:        latency,
+		QueueWait:      m.queueWait.summary(),
+	}
+	if latency.Count > 0 {
+		metrics.AverageDuration = latency.Sum / time.Duration(latency.Count)
+	}
+
+	m.workersMu.Lock()
+	for _, ws := range m.workers {
+		metrics.Workers = append(metrics.Workers, ws.snapshot(now))
+	}
+	m.workersMu.Unlock()
+
+	sort.Slice(metrics.Workers, func(i, j int) bool {
+		return metrics.Workers[i].ID < metrics.Workers[j].ID
+	})
+	return metrics
+}
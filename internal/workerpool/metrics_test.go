@@ -0,0 +1,164 @@
+package workerpool
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestHistogram_Summary(t *testing.T) {
+	var h histogram
+	// 90 tarefas rápidas e 10 lentas: a cauda só aparece nos percentis altos
+	for i := 0; i < 90; i++ {
+		h.observe(time.Millisecond)
+	}
+	for i := 0; i < 10; i++ {
+		h.observe(time.Second)
+	}
+
+	s := h.summary()
+	if s.Count != 100 {
+		t.Fatalf("Expected 100 observations, got %d", s.Count)
+	}
+	if s.Max != time.Second {
+		t.Errorf("Expected max 1s, got %v", s.Max)
+	}
+	if s.P50 > 2*time.Millisecond {
+		t.Errorf("Expected p50 near 1ms, got %v", s.P50)
+	}
+	if s.P95 < 500*time.Millisecond || s.P95 > time.Second {
+		t.Errorf("Expected p95 near 1s, got %v", s.P95)
+	}
+	if s.P99 > s.Max {
+		t.Errorf("Expected p99 <= max, got %v", s.P99)
+	}
+	if last := s.Buckets[len(s.Buckets)-1]; last.Count != s.Count {
+		t.Errorf("Expected last bucket to hold every observation, got %d", last.Count)
+	}
+}
+
+func TestHistogram_Empty(t *testing.T) {
+	var h histogram
+	s := h.summary()
+	if s.Count != 0 || s.P50 != 0 || s.P99 != 0 {
+		t.Errorf("Expected empty summary, got %+v", s)
+	}
+}
+
+func TestHistogram_OverflowBucket(t *testing.T) {
+	var h histogram
+	h.observe(10 * time.Minute)
+
+	s := h.summary()
+	// Acima do último limite, a estimativa fica entre esse limite e o máximo
+	if s.P99 <= bucketBound(histogramBuckets-1) || s.P99 > 10*time.Minute {
+		t.Errorf("Expected p99 between the last bound and max (10m), got %v", s.P99)
+	}
+}
+
+func TestHistogram_ConcurrentObserve(t *testing.T) {
+	var h histogram
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				h.observe(time.Duration(j) * time.Microsecond)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if s := h.summary(); s.Count != 8000 {
+		t.Errorf("Expected 8000 observations, got %d", s.Count)
+	}
+}
+
+func TestPool_MetricsQueueWaitAndWorkers(t *testing.T) {
+	pool := NewPool[int, int](1, 10)
+	pool.Start()
+	defer pool.Stop()
+
+	release := make(chan struct{})
+	started := make(chan struct{}, 2)
+	results := make(chan TypedResult[int, int], 2)
+
+	for i := 0; i < 2; i++ {
+		task := TypedTask[int, int]{
+			ID: i,
+			ContextHandler: func(ctx context.Context, n int) (int, error) {
+				started <- struct{}{}
+				<-release
+				return n, nil
+			},
+			Result: results,
+		}
+		if err := pool.Submit(task); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	<-started
+	metrics := pool.GetMetrics()
+	if metrics.TasksInFlight != 1 {
+		t.Errorf("Expected 1 task in flight, got %d", metrics.TasksInFlight)
+	}
+	if len(metrics.Workers) != 1 || !metrics.Workers[0].Busy {
+		t.Fatalf("Expected one busy worker, got %+v", metrics.Workers)
+	}
+
+	// A segunda tarefa espera na fila enquanto o único worker está ocupado
+	time.Sleep(50 * time.Millisecond)
+	release <- struct{}{}
+	<-started
+	release <- struct{}{}
+	<-results
+	<-results
+
+	// O worker marca o fim da tarefa logo após entregar o resultado
+	deadline := time.Now().Add(time.Second)
+	for pool.GetMetrics().TasksInFlight != 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	metrics = pool.GetMetrics()
+	if metrics.TasksInFlight != 0 {
+		t.Errorf("Expected no task in flight, got %d", metrics.TasksInFlight)
+	}
+	if metrics.QueueWait.Count != 2 {
+		t.Errorf("Expected 2 queue wait observations, got %d", metrics.QueueWait.Count)
+	}
+	if metrics.QueueWait.Max < 50*time.Millisecond {
+		t.Errorf("Expected the second task to wait at least 50ms, got %v", metrics.QueueWait.Max)
+	}
+	if metrics.Latency.Count != 2 {
+		t.Errorf("Expected 2 latency observations, got %d", metrics.Latency.Count)
+	}
+
+	worker := metrics.Workers[0]
+	if worker.TasksProcessed != 2 {
+		t.Errorf("Expected worker to process 2 tasks, got %d", worker.TasksProcessed)
+	}
+	if worker.BusyTime < 50*time.Millisecond || worker.Utilization <= 0 || worker.Utilization > 1 {
+		t.Errorf("Unexpected worker busy time %v / utilization %v", worker.BusyTime, worker.Utilization)
+	}
+}
+
+func TestPool_MetricsRetiredWorkers(t *testing.T) {
+	pool := NewPool[int, int](3, 10)
+	pool.Start()
+	defer pool.Stop()
+
+	pool.Resize(1)
+
+	// Workers aposentados saem assim que percebem o sinal
+	deadline := time.Now().Add(time.Second)
+	for len(pool.GetMetrics().Workers) != 1 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if workers := pool.GetMetrics().Workers; len(workers) != 1 || workers[0].ID != 0 {
+		t.Errorf("Expected only worker 0 to remain, got %+v", workers)
+	}
+}
@@ -0,0 +1,46 @@
+package workerpool
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Options configura um Pool criado com NewPoolWithOptions
+type Options struct {
+	// Workers é o número inicial de workers (mínimo 1)
+	Workers int
+	// QueueSize é a capacidade da fila de cada nível de prioridade
+	QueueSize int
+	// Logger recebe os eventos do pool como campos estruturados
+	// (worker_id, task_id, duration, error). nil = modo silencioso.
+	Logger *slog.Logger
+	// AgingInterval é o envelhecimento das tarefas na fila
+	// (zero = DefaultAgingInterval, negativo = sem envelhecimento)
+	AgingInterval time.Duration
+}
+
+// withDefaults normaliza as opções
+func (o Options) withDefaults() Options {
+	if o.Workers <= 0 {
+		o.Workers = 1
+	}
+	if o.QueueSize < 0 {
+		o.QueueSize = 0
+	}
+	if o.Logger == nil {
+		o.Logger = slog.New(discardHandler{})
+	}
+	if o.AgingInterval == 0 {
+		o.AgingInterval = DefaultAgingInterval
+	}
+	return o
+}
+
+// discardHandler é um slog.Handler que descarta todos os registros
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
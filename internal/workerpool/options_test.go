@@ -0,0 +1,106 @@
+package workerpool
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// syncBuffer é um bytes.Buffer seguro para escrita concorrente
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) Lines() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return strings.Split(strings.TrimSpace(b.buf.String()), "\n")
+}
+
+func TestOptions_Defaults(t *testing.T) {
+	opts := Options{Workers: -1, QueueSize: -1}.withDefaults()
+
+	if opts.Workers != 1 {
+		t.Errorf("Expected 1 worker, got %d", opts.Workers)
+	}
+	if opts.QueueSize != 0 {
+		t.Errorf("Expected queue size 0, got %d", opts.QueueSize)
+	}
+	if opts.Logger == nil {
+		t.Error("Expected silent logger, got nil")
+	}
+	if opts.AgingInterval != DefaultAgingInterval {
+		t.Errorf("Expected aging interval %v, got %v", DefaultAgingInterval, opts.AgingInterval)
+	}
+}
+
+func TestWorkerPool_StructuredLogging(t *testing.T) {
+	var out syncBuffer
+	logger := slog.New(slog.NewJSONHandler(&out, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	pool := NewWorkerPoolWithOptions(Options{Workers: 1, QueueSize: 10, Logger: logger})
+	pool.Start()
+
+	errChan := make(chan error, 1)
+	pool.Submit(Task{
+		ID: 7,
+		Handler: func(payload interface{}) (interface{}, error) {
+			return nil, errors.New("processing error")
+		},
+		Error: errChan,
+	})
+
+	select {
+	case <-errChan:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timeout waiting for error")
+	}
+	pool.Stop()
+
+	var failed map[string]interface{}
+	for _, line := range out.Lines() {
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("Expected JSON log line, got %q", line)
+		}
+		if entry["msg"] == "tarefa falhou" {
+			failed = entry
+		}
+	}
+
+	if failed == nil {
+		t.Fatalf("Expected 'tarefa falhou' event, got %v", out.Lines())
+	}
+	if failed["level"] != "WARN" {
+		t.Errorf("Expected level WARN, got %v", failed["level"])
+	}
+	if failed["worker_id"] != float64(0) || failed["task_id"] != float64(7) {
+		t.Errorf("Expected worker_id 0 and task_id 7, got %v and %v", failed["worker_id"], failed["task_id"])
+	}
+	if failed["error"] != "processing error" {
+		t.Errorf("Expected error field, got %v", failed["error"])
+	}
+	if _, ok := failed["duration"]; !ok {
+		t.Error("Expected duration field")
+	}
+}
+
+func TestWorkerPool_SilentByDefault(t *testing.T) {
+	pool := NewPool[int, int](1, 1)
+	if pool.logger.Enabled(context.Background(), slog.LevelError) {
+		t.Error("Expected default pool logger to be silent")
+	}
+}
@@ -2,85 +2,161 @@ package workerpool
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"runtime/debug"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
-// Task representa uma tarefa a ser processada
-type Task struct {
+// TypedHandler é a assinatura de um handler tipado que recebe o contexto da tarefa.
+// O contexto é cancelado quando o pool é parado ou quando o Timeout da tarefa expira.
+type TypedHandler[In, Out any] func(ctx context.Context, payload In) (Out, error)
+
+// AdaptHandler converte um handler sem contexto em um TypedHandler
+func AdaptHandler[In, Out any](handler func(In) (Out, error)) TypedHandler[In, Out] {
+	return func(_ context.Context, payload In) (Out, error) {
+		return handler(payload)
+	}
+}
+
+// TypedTask representa uma tarefa tipada a ser processada
+type TypedTask[In, Out any] struct {
 	ID      int
-	Payload interface{}
-	Handler func(interface{}) (interface{}, error)
-	Result  chan Result
-	Error   chan error
+	Payload In
+	// Handler é o handler legado, sem contexto. Ignorado se ContextHandler estiver definido.
+	Handler        func(In) (Out, error)
+	ContextHandler TypedHandler[In, Out]
+	// Priority define a ordem de entrega aos workers (padrão: PriorityNormal)
+	Priority Priority
+	// Timeout define o prazo máximo da tarefa, somando todas as tentativas (zero = sem prazo)
+	Timeout time.Duration
+	// Deadline define um instante limite para a tarefa (zero = sem limite).
+	// Se Timeout também for definido, vale o prazo que expirar primeiro.
+	Deadline time.Time
+	// Retry define a política de novas tentativas em caso de erro (nil = sem retry)
+	Retry  *RetryPolicy
+	Result chan TypedResult[In, Out]
+	Error  chan error
+}
+
+// handler retorna o handler efetivo da tarefa
+func (t TypedTask[In, Out]) handler() TypedHandler[In, Out] {
+	if t.ContextHandler != nil {
+		return t.ContextHandler
+	}
+	if t.Handler != nil {
+		return AdaptHandler(t.Handler)
+	}
+	return func(context.Context, In) (Out, error) {
+		var zero Out
+		return zero, ErrNoHandler
+	}
 }
 
-// Result representa o resultado do processamento
-type Result struct {
+// TypedResult representa o resultado tipado do processamento
+type TypedResult[In, Out any] struct {
 	TaskID   int
-	Payload  interface{}
-	Output   interface{}
+	Payload  In
+	Output   Out
 	Duration time.Duration
+	Attempts int
 }
 
-// WorkerPool gerencia um pool de workers
-type WorkerPool struct {
-	workerCount int
-	taskQueue   chan Task
-	workerPool  chan chan Task
-	ctx         context.Context
-	cancel      context.CancelFunc
-	wg          sync.WaitGroup
+// Pool gerencia um pool de workers que processa tarefas tipadas
+type Pool[In, Out any] struct {
+	// workerCount é o número desejado de workers; workers mapeia o ID de cada
+	// worker ativo para o canal que o aposenta. Ambos protegidos por workersMu.
+	workerCount  int
+	workers      map[int]chan struct{}
+	nextWorkerID int
+	workersMu    sync.Mutex
+	// liveWorkers conta as goroutines de worker em execução, inclusive as
+	// aposentadas que ainda terminam a tarefa atual
+	liveWorkers atomic.Int64
+
+	taskQueues [priorityLevels]chan queuedTask[In, Out]
+	queueDepth [priorityLevels]atomic.Int64
+	workQueue  chan queuedTask[In, Out]
+	ctx        context.Context
+	cancel     context.CancelFunc
+	wg         sync.WaitGroup
+	// handlers conta os handlers abandonados por execute ao esgotar o prazo da
+	// tarefa, que podem seguir rodando depois que o worker (e o pool) terminou
+	handlers    sync.WaitGroup
 	started     bool
+	queueClosed bool
 	mu          sync.RWMutex
-	metrics     *Metrics
+	metrics     *metricsCollector
+
+	// intake é fechado quando o pool deixa de aceitar novas tarefas
+	intake     chan struct{}
+	intakeOnce sync.Once
+	// dispatcherDone é fechado quando o dispatcher encerra
+	dispatcherDone chan struct{}
+	// abandoned guarda as tarefas que o dispatcher tinha em mãos ao ser cancelado
+	abandoned []TypedTask[In, Out]
+	// agingInterval controla o envelhecimento das tarefas na fila
+	agingInterval time.Duration
+	logger        *slog.Logger
+	// middleware e hooks são definidos antes de Start e apenas lidos depois
+	middleware []Middleware[In, Out]
+	hooks      []Hooks[In, Out]
 }
 
-// Metrics armazena métricas do worker pool
-type Metrics struct {
-	TasksProcessed  int64
-	TasksFailed     int64
-	TotalDuration   time.Duration
-	AverageDuration time.Duration
-	mu              sync.RWMutex
+// NewPool cria uma nova instância do Pool, sem logs
+func NewPool[In, Out any](workerCount int, queueSize int) *Pool[In, Out] {
+	return NewPoolWithOptions[In, Out](Options{
+		Workers:   workerCount,
+		QueueSize: queueSize,
+	})
 }
 
-// NewWorkerPool cria uma nova instância do WorkerPool
-func NewWorkerPool(workerCount int, queueSize int) *WorkerPool {
-	if workerCount <= 0 {
-		workerCount = 1
-	}
-	if queueSize < 0 {
-		queueSize = 0
-	}
+// NewPoolWithOptions cria uma nova instância do Pool a partir de Options
+func NewPoolWithOptions[In, Out any](opts Options) *Pool[In, Out] {
+	opts = opts.withDefaults()
 
 	ctx, cancel := context.WithCancel(context.Background())
 
-	return &WorkerPool{
-		workerCount: workerCount,
-		taskQueue:   make(chan Task, queueSize),
-		workerPool:  make(chan chan Task, workerCount),
-		ctx:         ctx,
-		cancel:      cancel,
-		metrics:     &Metrics{},
+	wp := &Pool[In, Out]{
+		workerCount:    opts.Workers,
+		workers:        make(map[int]chan struct{}),
+		workQueue:      make(chan queuedTask[In, Out]),
+		ctx:            ctx,
+		cancel:         cancel,
+		metrics:        newMetricsCollector(),
+		intake:         make(chan struct{}),
+		dispatcherDone: make(chan struct{}),
+		agingInterval:  opts.AgingInterval,
+		logger:         opts.Logger,
 	}
+
+	// Cada nível de prioridade tem sua própria fila com a capacidade configurada
+	for level := range wp.taskQueues {
+		wp.taskQueues[level] = make(chan queuedTask[In, Out], opts.QueueSize)
+	}
+
+	return wp
 }
 
 // Start inicia o worker pool
-func (wp *WorkerPool) Start() {
+func (wp *Pool[In, Out]) Start() {
 	wp.mu.Lock()
 	defer wp.mu.Unlock()
 
-	if wp.started {
+	// Um pool parado não pode ser reiniciado: suas filas já foram fechadas
+	if wp.started || wp.queueClosed {
 		return
 	}
 
 	// Inicia workers
+	wp.workersMu.Lock()
 	for i := 0; i < wp.workerCount; i++ {
-		wp.wg.Add(1)
-		go wp.worker(i)
+		wp.addWorker()
 	}
+	wp.workersMu.Unlock()
 
 	// Inicia dispatcher
 	wp.wg.Add(1)
@@ -89,23 +165,135 @@ func (wp *WorkerPool) Start() {
 	wp.started = true
 }
 
-// Stop para o worker pool
-func (wp *WorkerPool) Stop() {
-	wp.mu.Lock()
-	defer wp.mu.Unlock()
-
-	if !wp.started {
+// Stop para o worker pool imediatamente.
+// Tarefas em execução têm o contexto cancelado e tarefas ainda na fila são descartadas.
+// Handlers já abandonados por prazo esgotado não são aguardados (veja Wait).
+func (wp *Pool[In, Out]) Stop() {
+	if !wp.IsRunning() {
 		return
 	}
 
-	close(wp.taskQueue)
+	wp.closeIntake()
 	wp.cancel()
+	wp.closeQueue()
+
 	wp.wg.Wait()
+	wp.markStopped()
+}
+
+// Shutdown encerra o pool de forma graciosa: para de aceitar tarefas e aguarda
+// que as tarefas em execução e as que ainda estão na fila terminem.
+// Se ctx terminar antes, o contexto das tarefas em execução é cancelado e
+// Shutdown retorna imediatamente as tarefas que nunca foram executadas, junto
+// com ctx.Err(); handlers que ignoram o contexto terminam em segundo plano
+// (veja Wait).
+func (wp *Pool[In, Out]) Shutdown(ctx context.Context) ([]TypedTask[In, Out], error) {
+	if !wp.IsRunning() {
+		return nil, nil
+	}
+
+	// Com a fila fechada, o dispatcher entrega o que restou e encerra os workers
+	wp.closeIntake()
+	wp.closeQueue()
+
+	done := make(chan struct{})
+	go func() {
+		wp.wg.Wait()
+		close(done)
+	}()
+
+	var err error
+	select {
+	case <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+		// Basta aguardar o dispatcher para que as tarefas pendentes sejam conhecidas
+		wp.cancel()
+		<-wp.dispatcherDone
+	}
+	wp.cancel()
+	wp.markStopped()
+
+	return wp.pendingTasks(), err
+}
+
+// Wait aguarda que os workers terminem, inclusive os que ainda executam
+// handlers após um Shutdown com prazo esgotado, e que terminem os handlers
+// abandonados por tarefas com prazo esgotado (veja TypedTask.Timeout), que
+// Stop e Shutdown não aguardam. Deve ser chamado após Stop ou Shutdown, antes
+// de liberar recursos usados pelos handlers.
+// Retorna ctx.Err() se ctx terminar antes.
+func (wp *Pool[In, Out]) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		// Após o fim dos workers, nenhum novo handler é abandonado
+		wp.wg.Wait()
+		wp.handlers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// closeQueue fecha a fila de tarefas uma única vez.
+// Submit e SubmitWait verificam intake sob o mesmo lock, então nenhum envio ocorre após o fechamento.
+func (wp *Pool[In, Out]) closeQueue() {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+
+	if !wp.queueClosed {
+		for _, queue := range wp.taskQueues {
+			close(queue)
+		}
+		wp.queueClosed = true
+	}
+}
+
+// markStopped marca o pool como parado
+func (wp *Pool[In, Out]) markStopped() {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
 	wp.started = false
 }
 
+// pendingTasks retorna as tarefas que nunca chegaram a um worker.
+// Deve ser chamado apenas após o dispatcher ter encerrado.
+func (wp *Pool[In, Out]) pendingTasks() []TypedTask[In, Out] {
+	pending := wp.abandoned
+	wp.abandoned = nil
+	for level := priorityLevels - 1; level >= 0; level-- {
+		for qt := range wp.taskQueues[level] {
+			wp.queueDepth[level].Add(-1)
+			pending = append(pending, qt.task)
+		}
+	}
+	return pending
+}
+
+// closeIntake impede que novas tarefas sejam aceitas
+func (wp *Pool[In, Out]) closeIntake() {
+	wp.intakeOnce.Do(func() {
+		close(wp.intake)
+	})
+}
+
 // Submit adiciona uma tarefa ao pool
-func (wp *WorkerPool) Submit(task Task) error {
+func (wp *Pool[In, Out]) Submit(task TypedTask[In, Out]) error {
+	qt := wp.enqueue(task)
+	if err := wp.submit(qt); err != nil {
+		return err
+	}
+	wp.accepted(qt)
+	return nil
+}
+
+// submit coloca a tarefa na fila sem bloquear
+func (wp *Pool[In, Out]) submit(qt queuedTask[In, Out]) error {
 	wp.mu.RLock()
 	defer wp.mu.RUnlock()
 
@@ -114,151 +302,496 @@ func (wp *WorkerPool) Submit(task Task) error {
 	}
 
 	select {
-	case wp.taskQueue <- task:
+	case <-wp.intake:
+		return ErrPoolStopped
+	default:
+	}
+
+	level := qt.task.Priority.level()
+	wp.queueDepth[level].Add(1)
+
+	select {
+	case wp.taskQueues[level] <- qt:
 		return nil
 	case <-wp.ctx.Done():
+		wp.queueDepth[level].Add(-1)
 		return ErrPoolStopped
 	default:
+		wp.queueDepth[level].Add(-1)
 		return ErrQueueFull
 	}
 }
 
-// dispatcher distribui tarefas para workers disponíveis
-func (wp *WorkerPool) dispatcher() {
+// SubmitWait adiciona uma tarefa ao pool, bloqueando até haver espaço na fila.
+// Retorna ErrPoolStopped se o pool for parado e ctx.Err() se o contexto terminar antes.
+func (wp *Pool[In, Out]) SubmitWait(ctx context.Context, task TypedTask[In, Out]) error {
+	qt := wp.enqueue(task)
+	if err := wp.submitWait(ctx, qt); err != nil {
+		return err
+	}
+	wp.accepted(qt)
+	return nil
+}
+
+// submitWait coloca a tarefa na fila, aguardando espaço
+func (wp *Pool[In, Out]) submitWait(ctx context.Context, qt queuedTask[In, Out]) error {
+	wp.mu.RLock()
+	defer wp.mu.RUnlock()
+
+	if !wp.started {
+		return ErrPoolNotStarted
+	}
+
+	// Um pool parado tem prioridade sobre uma fila com espaço
+	select {
+	case <-wp.intake:
+		return ErrPoolStopped
+	default:
+	}
+
+	level := qt.task.Priority.level()
+	wp.queueDepth[level].Add(1)
+
+	select {
+	case wp.taskQueues[level] <- qt:
+		return nil
+	case <-wp.intake:
+		wp.queueDepth[level].Add(-1)
+		return ErrPoolStopped
+	case <-ctx.Done():
+		wp.queueDepth[level].Add(-1)
+		return ctx.Err()
+	}
+}
+
+// enqueue prepara a tarefa para a fila, registrando o instante de entrada.
+// Com hooks OnSubmit, a tarefa leva o canal que libera sua execução.
+func (wp *Pool[In, Out]) enqueue(task TypedTask[In, Out]) queuedTask[In, Out] {
+	qt := queuedTask[In, Out]{task: task, enqueuedAt: time.Now()}
+	if wp.hasOnSubmit() {
+		qt.submitted = make(chan struct{})
+	}
+	return qt
+}
+
+// accepted chama OnSubmit para uma tarefa já aceita na fila, fora do lock do
+// pool, e só então libera sua execução
+func (wp *Pool[In, Out]) accepted(qt queuedTask[In, Out]) {
+	if qt.submitted == nil {
+		return
+	}
+	defer close(qt.submitted)
+	wp.onSubmit(qt.task)
+}
+
+// awaitSubmitted aguarda o fim de OnSubmit antes de executar a tarefa.
+// Se o pool estiver parando (por exemplo, por Stop chamado no próprio hook),
+// a tarefa segue sem esperar, para não travar o encerramento.
+func (wp *Pool[In, Out]) awaitSubmitted(qt queuedTask[In, Out]) {
+	if qt.submitted == nil {
+		return
+	}
+	select {
+	case <-qt.submitted:
+	case <-wp.intake:
+	case <-wp.ctx.Done():
+	}
+}
+
+// TrySubmitTimeout adiciona uma tarefa ao pool, aguardando no máximo timeout por espaço na fila.
+// Retorna ErrSubmitTimeout se o prazo expirar.
+func (wp *Pool[In, Out]) TrySubmitTimeout(task TypedTask[In, Out], timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	err := wp.SubmitWait(ctx, task)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrSubmitTimeout
+	}
+	return err
+}
+
+// dispatcher distribui tarefas para workers disponíveis, servindo primeiro os
+// níveis de prioridade mais altos. O dispatcher mantém em mãos a tarefa mais
+// antiga de cada nível e reavalia a escolha sempre que chega uma tarefa nova ou
+// o envelhecimento pode ter mudado a ordem.
+// Ao encerrar, fecha workQueue para que os workers terminem após a tarefa atual.
+func (wp *Pool[In, Out]) dispatcher() {
 	defer wp.wg.Done()
+	defer close(wp.workQueue)
+	defer close(wp.dispatcherDone)
+
+	var heads [priorityLevels]*queuedTask[In, Out]
+	queues := wp.taskQueues
+
+	receive := func(level int, qt queuedTask[In, Out], ok bool) {
+		if !ok {
+			queues[level] = nil // fila fechada e vazia
+			return
+		}
+		heads[level] = &qt
+	}
+
+	// source retorna a fila de onde o nível pode receber, ou nil se já há tarefa em mãos
+	source := func(level int) chan queuedTask[In, Out] {
+		if heads[level] != nil {
+			return nil
+		}
+		return queues[level]
+	}
+
+	// Sem envelhecimento, o canal nil nunca dispara
+	var agingTick <-chan time.Time
+	if wp.agingInterval > 0 {
+		aging := time.NewTicker(wp.agingInterval)
+		defer aging.Stop()
+		agingTick = aging.C
+	}
 
 	for {
-		select {
-		case task, ok := <-wp.taskQueue:
-			if !ok {
-				return
+		for level := range queues {
+			if source(level) == nil {
+				continue
 			}
 			select {
-			case workerTaskQueue := <-wp.workerPool:
-				select {
-				case workerTaskQueue <- task:
-				case <-wp.ctx.Done():
-					return
-				}
-			case <-wp.ctx.Done():
-				return
+			case qt, ok := <-queues[level]:
+				receive(level, qt, ok)
+			default:
 			}
+		}
 
+		next := nextLevel(&heads, wp.agingInterval, time.Now())
+
+		var out chan queuedTask[In, Out]
+		var task queuedTask[In, Out]
+		if next >= 0 {
+			out = wp.workQueue
+			task = *heads[next]
+		} else if queues == [priorityLevels]chan queuedTask[In, Out]{} {
+			return
+		}
+
+		// Um case por nível de prioridade (priorityLevels = 3)
+		select {
+		case out <- task:
+			heads[next] = nil
+		case qt, ok := <-source(0):
+			receive(0, qt, ok)
+		case qt, ok := <-source(1):
+			receive(1, qt, ok)
+		case qt, ok := <-source(2):
+			receive(2, qt, ok)
+		case <-agingTick:
 		case <-wp.ctx.Done():
+			for level := priorityLevels - 1; level >= 0; level-- {
+				if heads[level] != nil {
+					wp.queueDepth[level].Add(-1)
+					wp.abandoned = append(wp.abandoned, heads[level].task)
+				}
+			}
 			return
 		}
 	}
 }
 
-// worker processa tarefas
-func (wp *WorkerPool) worker(id int) {
+// worker processa tarefas até a fila de trabalho ser fechada ou o worker ser aposentado
+func (wp *Pool[In, Out]) worker(id int, retire <-chan struct{}) {
 	defer wp.wg.Done()
 
-	workerTaskQueue := make(chan Task)
-	defer close(workerTaskQueue)
-
-	// Log quando worker inicia
-	fmt.Printf("  👷 Worker #%d iniciado e aguardando tarefas...\n", id)
+	stats := wp.metrics.registerWorker(id)
+	retired := false
 
-	go func() {
-		for {
-			select {
-			case wp.workerPool <- workerTaskQueue:
-			case <-wp.ctx.Done():
-				return
-			}
-		}
+	wp.logger.Debug("worker iniciado", "worker_id", id)
+	wp.onWorkerStart(id)
+	defer func() {
+		wp.liveWorkers.Add(-1)
+		wp.metrics.stopWorker(stats, retired)
+		wp.onWorkerStop(id)
+		wp.logger.Debug("worker finalizado", "worker_id", id)
 	}()
 
 	for {
+		// Um worker aposentado não pega novas tarefas, mesmo com a fila cheia
 		select {
-		case task := <-workerTaskQueue:
-			wp.processTask(task, id)
+		case <-retire:
+			retired = true
+			return
+		default:
+		}
 
-		case <-wp.ctx.Done():
-			fmt.Printf("  🛑 Worker #%d finalizado\n", id)
+		select {
+		case qt, ok := <-wp.workQueue:
+			if !ok {
+				return
+			}
+			wp.awaitSubmitted(qt)
+			wp.queueDepth[qt.task.Priority.level()].Add(-1)
+			wp.metrics.taskStarted(stats, qt.enqueuedAt)
+			wp.processTask(qt.task, id)
+			wp.metrics.taskFinished(stats)
+
+		case <-retire:
+			retired = true
 			return
 		}
 	}
 }
 
+// addWorker inicia um novo worker. Deve ser chamado com workersMu adquirido
+// e com o pool em execução.
+func (wp *Pool[In, Out]) addWorker() {
+	id := wp.nextWorkerID
+	wp.nextWorkerID++
+
+	retire := make(chan struct{})
+	wp.workers[id] = retire
+
+	wp.wg.Add(1)
+	wp.liveWorkers.Add(1)
+	go wp.worker(id, retire)
+}
+
+// Resize altera o número de workers em tempo de execução.
+// Novos workers começam imediatamente; workers aposentados terminam a tarefa
+// atual antes de sair, sem que nenhuma tarefa da fila seja perdida.
+// Com o pool parado, apenas define quantos workers Start vai iniciar.
+func (wp *Pool[In, Out]) Resize(workerCount int) {
+	if workerCount <= 0 {
+		workerCount = 1
+	}
+
+	wp.mu.RLock()
+	defer wp.mu.RUnlock()
+
+	wp.workersMu.Lock()
+	defer wp.workersMu.Unlock()
+
+	wp.workerCount = workerCount
+	if !wp.started || wp.queueClosed {
+		return
+	}
+
+	for len(wp.workers) < workerCount {
+		wp.addWorker()
+	}
+
+	// Aposenta os workers mais recentes primeiro
+	for id := wp.nextWorkerID - 1; len(wp.workers) > workerCount; id-- {
+		if retire, ok := wp.workers[id]; ok {
+			close(retire)
+			delete(wp.workers, id)
+		}
+	}
+}
+
 // processTask executa uma tarefa
-func (wp *WorkerPool) processTask(task Task, workerID int) {
+func (wp *Pool[In, Out]) processTask(task TypedTask[In, Out], workerID int) {
 	startTime := time.Now()
 
-	// Tenta extrair informação do payload para log mais detalhado
-	payloadInfo := ""
-	if task.Payload != nil {
-		// Se o payload tiver método String() ou campos específicos, podemos usá-los
-		if rec, ok := task.Payload.(interface{ GetName() string }); ok {
-			payloadInfo = fmt.Sprintf(" - %s", rec.GetName())
-		}
+	// Campos comuns a todos os eventos da tarefa
+	attrs := []any{"worker_id", workerID, "task_id", task.ID}
+	// Se o payload tiver método GetName(), usamos para o log
+	if rec, ok := any(task.Payload).(interface{ GetName() string }); ok {
+		attrs = append(attrs, "name", rec.GetName())
 	}
 
-	// Log quando worker recebe tarefa
-	fmt.Printf("  [Worker #%d] ⚙️  Recebeu tarefa #%d%s\n", workerID, task.ID, payloadInfo)
+	wp.logger.Debug("tarefa recebida", attrs...)
+
+	ctx, cancel := wp.taskContext(task)
+	defer cancel()
 
-	result, err := task.Handler(task.Payload)
+	wp.beforeExecute(ctx, TaskEvent[In, Out]{Task: task, WorkerID: workerID})
+	result, attempts, err := wp.execute(ctx, task, workerID)
 	duration := time.Since(startTime)
 
-	wp.updateMetrics(err, duration)
+	wp.afterExecute(ctx, TaskEvent[In, Out]{
+		Task:     task,
+		WorkerID: workerID,
+		Output:   result,
+		Err:      err,
+		Attempts: attempts,
+		Duration: duration,
+	})
+
+	wp.metrics.record(err, duration, attempts)
+
+	attrs = append(attrs, "duration", duration, "attempts", attempts)
 
 	if err != nil {
-		fmt.Printf("  [Worker #%d] ❌ Tarefa #%d FALHOU após %v: %v\n", workerID, task.ID, duration, err)
+		wp.logger.Warn("tarefa falhou", append(attrs, "error", err)...)
 		if task.Error != nil {
 			task.Error <- err
 		}
 		return
 	}
 
-	fmt.Printf("  [Worker #%d] ✅ Tarefa #%d concluída em %v%s\n", workerID, task.ID, duration, payloadInfo)
+	wp.logger.Debug("tarefa concluída", attrs...)
 
 	if task.Result != nil {
-		task.Result <- Result{
+		task.Result <- TypedResult[In, Out]{
 			TaskID:   task.ID,
 			Payload:  task.Payload,
 			Output:   result,
 			Duration: duration,
+			Attempts: attempts,
 		}
 	}
 }
 
-// updateMetrics atualiza as métricas
-func (wp *WorkerPool) updateMetrics(err error, duration time.Duration) {
-	wp.metrics.mu.Lock()
-	defer wp.metrics.mu.Unlock()
+// execute executa a tarefa aplicando o prazo definido em seu contexto e
+// retorna o número de tentativas iniciadas.
+// Se o prazo expirar, ErrTaskTimeout é retornado mesmo que o handler ignore o contexto;
+// nesse caso o handler continua em segundo plano (veja Wait) e seu resultado é descartado.
+func (wp *Pool[In, Out]) execute(ctx context.Context, task TypedTask[In, Out], workerID int) (Out, int, error) {
+	// O contador é compartilhado para que o prazo esgotado informe as tentativas já feitas
+	var attempts atomic.Int64
+
+	if _, ok := ctx.Deadline(); !ok {
+		result, err := wp.runWithRetry(ctx, task, workerID, &attempts)
+		return result, int(attempts.Load()), err
+	}
 
-	wp.metrics.TasksProcessed++
-	wp.metrics.TotalDuration += duration
-	if wp.metrics.TasksProcessed > 0 {
-		wp.metrics.AverageDuration = wp.metrics.TotalDuration / time.Duration(wp.metrics.TasksProcessed)
+	type outcome struct {
+		result Out
+		err    error
 	}
 
-	if err != nil {
-		wp.metrics.TasksFailed++
+	done := make(chan outcome, 1)
+	wp.handlers.Add(1)
+	go func() {
+		defer wp.handlers.Done()
+		result, err := wp.runWithRetry(ctx, task, workerID, &attempts)
+		done <- outcome{result, err}
+	}()
+
+	select {
+	case o := <-done:
+		if o.err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			o.err = fmt.Errorf("%w: %w", ErrTaskTimeout, o.err)
+		}
+		return o.result, int(attempts.Load()), o.err
+
+	case <-ctx.Done():
+		// Cancelamento do pool (Stop) aguarda o handler; só o prazo o abandona
+		if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			o := <-done
+			return o.result, int(attempts.Load()), o.err
+		}
+		var zero Out
+		return zero, int(attempts.Load()), fmt.Errorf("%w: %w", ErrTaskTimeout, ctx.Err())
+	}
+}
+
+// runWithRetry executa o handler da tarefa, repetindo conforme a política de retry.
+// Cada tentativa iniciada é somada a attempts.
+func (wp *Pool[In, Out]) runWithRetry(ctx context.Context, task TypedTask[In, Out], workerID int, attempts *atomic.Int64) (Out, error) {
+	handler := wp.wrap(task.handler())
+
+	for attempt := 1; ; attempt++ {
+		attempts.Add(1)
+		result, err := callHandler(ctx, handler, task)
+
+		// Um panic indica um bug no handler: não há por que tentar de novo
+		if errors.Is(err, ErrTaskPanicked) {
+			var panicErr *PanicError
+			errors.As(err, &panicErr)
+			wp.logger.Error("panic no handler da tarefa",
+				"worker_id", workerID, "task_id", task.ID, "error", err, "stack", string(panicErr.Stack))
+			return result, err
+		}
+		if !task.Retry.shouldRetry(err, attempt) {
+			return result, err
+		}
+
+		wp.logger.Info("nova tentativa da tarefa",
+			"worker_id", workerID, "task_id", task.ID, "attempt", attempt, "error", err)
+
+		if waitErr := task.Retry.wait(ctx, attempt); waitErr != nil {
+			return result, err
+		}
+	}
+}
+
+// callHandler executa o handler convertendo um panic em *PanicError,
+// para que o worker continue disponível para as próximas tarefas
+func callHandler[In, Out any](ctx context.Context, handler TypedHandler[In, Out], task TypedTask[In, Out]) (result Out, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{
+				TaskID: task.ID,
+				Value:  r,
+				Stack:  debug.Stack(),
+			}
+		}
+	}()
+
+	return handler(ctx, task.Payload)
+}
+
+// taskContext cria o contexto de execução da tarefa, derivado do contexto do pool
+func (wp *Pool[In, Out]) taskContext(task TypedTask[In, Out]) (context.Context, context.CancelFunc) {
+	deadline := task.Deadline
+	if task.Timeout > 0 {
+		if byTimeout := time.Now().Add(task.Timeout); deadline.IsZero() || byTimeout.Before(deadline) {
+			deadline = byTimeout
+		}
+	}
+
+	if !deadline.IsZero() {
+		return context.WithDeadline(wp.ctx, deadline)
 	}
+	return context.WithCancel(wp.ctx)
 }
 
-// GetMetrics retorna as métricas atuais
-func (wp *WorkerPool) GetMetrics() Metrics {
-	wp.metrics.mu.RLock()
-	defer wp.metrics.mu.RUnlock()
+// GetMetrics retorna uma fotografia das métricas atuais
+func (wp *Pool[In, Out]) GetMetrics() Metrics {
+	return wp.metrics.snapshot()
+}
 
-	return Metrics{
-		TasksProcessed:  wp.metrics.TasksProcessed,
-		TasksFailed:     wp.metrics.TasksFailed,
-		TotalDuration:   wp.metrics.TotalDuration,
-		AverageDuration: wp.metrics.AverageDuration,
+// QueueDepth retorna o número de tarefas aguardando um worker
+func (wp *Pool[In, Out]) QueueDepth() int {
+	total := 0
+	for level := range wp.queueDepth {
+		total += int(wp.queueDepth[level].Load())
+	}
+	return total
+}
+
+// QueueDepthByPriority retorna o número de tarefas aguardando um worker, por prioridade
+func (wp *Pool[In, Out]) QueueDepthByPriority() map[Priority]int {
+	depths := make(map[Priority]int, priorityLevels)
+	for level := range wp.queueDepth {
+		depths[PriorityLow+Priority(level)] = int(wp.queueDepth[level].Load())
+	}
+	return depths
+}
+
+// GetWorkerCount retorna o número de workers em execução. Workers aposentados
+// por Resize contam até terminarem a tarefa atual. Antes de Start, retorna o
+// número configurado; depois de Stop ou Shutdown, zero.
+func (wp *Pool[In, Out]) GetWorkerCount() int {
+	wp.mu.RLock()
+	defer wp.mu.RUnlock()
+
+	if wp.started {
+		return int(wp.liveWorkers.Load())
+	}
+	if wp.queueClosed {
+		return 0
 	}
+	return wp.targetWorkers()
 }
 
-// GetWorkerCount retorna o número de workers
-func (wp *WorkerPool) GetWorkerCount() int {
+// targetWorkers retorna o número desejado de workers, definido na criação
+// ou pelo último Resize
+func (wp *Pool[In, Out]) targetWorkers() int {
+	wp.workersMu.Lock()
+	defer wp.workersMu.Unlock()
 	return wp.workerCount
 }
 
 // IsRunning verifica se o pool está em execução
-func (wp *WorkerPool) IsRunning() bool {
+func (wp *Pool[In, Out]) IsRunning() bool {
 	wp.mu.RLock()
 	defer wp.mu.RUnlock()
 	return wp.started
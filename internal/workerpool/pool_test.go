@@ -1,8 +1,11 @@
 package workerpool
 
 import (
+	"context"
 	"errors"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -301,3 +304,820 @@ func TestWorkerPool_MetricsAfterStop(t *testing.T) {
 	}
 }
 
+func TestWorkerPool_ContextHandler(t *testing.T) {
+	pool := NewWorkerPool(2, 10)
+	pool.Start()
+	defer pool.Stop()
+
+	task := Task{
+		ID:      1,
+		Payload: "test",
+		ContextHandler: func(ctx context.Context, payload interface{}) (interface{}, error) {
+			if ctx == nil {
+				return nil, errors.New("nil context")
+			}
+			return payload, nil
+		},
+		Result: make(chan Result, 1),
+	}
+
+	if err := pool.Submit(task); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	select {
+	case result := <-task.Result:
+		if result.Output != "test" {
+			t.Errorf("Expected output 'test', got %v", result.Output)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timeout waiting for result")
+	}
+}
+
+func TestWorkerPool_ContextCancelledOnStop(t *testing.T) {
+	pool := NewWorkerPool(1, 10)
+	pool.Start()
+
+	started := make(chan struct{})
+	cancelled := make(chan error, 1)
+
+	task := Task{
+		ID: 1,
+		ContextHandler: func(ctx context.Context, payload interface{}) (interface{}, error) {
+			close(started)
+			<-ctx.Done()
+			cancelled <- ctx.Err()
+			return nil, ctx.Err()
+		},
+	}
+	pool.Submit(task)
+
+	<-started
+	pool.Stop()
+
+	select {
+	case err := <-cancelled:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Handler context was not cancelled on Stop()")
+	}
+}
+
+func TestWorkerPool_ContextTaskTimeout(t *testing.T) {
+	pool := NewWorkerPool(1, 10)
+	pool.Start()
+	defer pool.Stop()
+
+	errChan := make(chan error, 1)
+	task := Task{
+		ID:      1,
+		Timeout: 20 * time.Millisecond,
+		ContextHandler: func(ctx context.Context, payload interface{}) (interface{}, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+		Error: errChan,
+	}
+	pool.Submit(task)
+
+	select {
+	case err := <-errChan:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timeout waiting for deadline")
+	}
+}
+
+func TestAdaptHandler(t *testing.T) {
+	handler := AdaptHandler(func(payload interface{}) (interface{}, error) {
+		return payload.(int) * 2, nil
+	})
+
+	out, err := handler(context.Background(), 21)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if out != 42 {
+		t.Errorf("Expected 42, got %v", out)
+	}
+}
+
+func TestPool_Typed(t *testing.T) {
+	pool := NewPool[int, string](2, 10)
+	pool.Start()
+	defer pool.Stop()
+
+	task := TypedTask[int, string]{
+		ID:      1,
+		Payload: 7,
+		ContextHandler: func(ctx context.Context, n int) (string, error) {
+			return strings.Repeat("x", n), nil
+		},
+		Result: make(chan TypedResult[int, string], 1),
+	}
+
+	if err := pool.Submit(task); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	select {
+	case result := <-task.Result:
+		if result.Payload != 7 {
+			t.Errorf("Expected payload 7, got %d", result.Payload)
+		}
+		if result.Output != "xxxxxxx" {
+			t.Errorf("Expected output 'xxxxxxx', got %q", result.Output)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timeout waiting for result")
+	}
+}
+
+func TestPool_TypedWithoutHandler(t *testing.T) {
+	pool := NewPool[int, int](1, 10)
+	pool.Start()
+	defer pool.Stop()
+
+	errChan := make(chan error, 1)
+	pool.Submit(TypedTask[int, int]{ID: 1, Error: errChan})
+
+	select {
+	case err := <-errChan:
+		if !errors.Is(err, ErrNoHandler) {
+			t.Errorf("Expected ErrNoHandler, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timeout waiting for error")
+	}
+}
+
+// blockingTask cria uma tarefa que só termina quando release for fechado
+func blockingTask(id int, release chan struct{}) Task {
+	return Task{
+		ID: id,
+		Handler: func(payload interface{}) (interface{}, error) {
+			<-release
+			return nil, nil
+		},
+	}
+}
+
+func TestWorkerPool_SubmitWait(t *testing.T) {
+	pool := NewWorkerPool(1, 1)
+	pool.Start()
+	defer pool.Stop()
+
+	release := make(chan struct{})
+	for i := 0; i < 3; i++ {
+		pool.SubmitWait(context.Background(), blockingTask(i, release))
+	}
+
+	submitted := make(chan error, 1)
+	go func() {
+		submitted <- pool.SubmitWait(context.Background(), blockingTask(99, release))
+	}()
+
+	select {
+	case err := <-submitted:
+		t.Fatalf("Expected SubmitWait to block with full queue, returned %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+
+	select {
+	case err := <-submitted:
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("SubmitWait did not unblock after queue drained")
+	}
+}
+
+func TestWorkerPool_SubmitWaitContextCancelled(t *testing.T) {
+	pool := NewWorkerPool(1, 1)
+	pool.Start()
+
+	release := make(chan struct{})
+	defer pool.Stop()
+	defer close(release)
+
+	for i := 0; i < 3; i++ {
+		pool.TrySubmitTimeout(blockingTask(i, release), 20*time.Millisecond)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := pool.SubmitWait(ctx, blockingTask(99, release))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestWorkerPool_TrySubmitTimeout(t *testing.T) {
+	pool := NewWorkerPool(1, 1)
+	pool.Start()
+
+	release := make(chan struct{})
+	defer pool.Stop()
+	defer close(release)
+
+	for i := 0; i < 3; i++ {
+		pool.TrySubmitTimeout(blockingTask(i, release), 20*time.Millisecond)
+	}
+
+	err := pool.TrySubmitTimeout(blockingTask(99, release), 20*time.Millisecond)
+	if err != ErrSubmitTimeout {
+		t.Errorf("Expected ErrSubmitTimeout, got %v", err)
+	}
+}
+
+func TestWorkerPool_SubmitWaitStopped(t *testing.T) {
+	pool := NewWorkerPool(1, 1)
+	pool.Start()
+
+	release := make(chan struct{})
+	for i := 0; i < 3; i++ {
+		pool.TrySubmitTimeout(blockingTask(i, release), 20*time.Millisecond)
+	}
+
+	submitted := make(chan error, 1)
+	go func() {
+		submitted <- pool.SubmitWait(context.Background(), blockingTask(99, release))
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	close(release)
+	pool.Stop()
+
+	select {
+	case err := <-submitted:
+		if err != nil && err != ErrPoolStopped && err != ErrPoolNotStarted {
+			t.Errorf("Expected ErrPoolStopped, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("SubmitWait did not return after Stop()")
+	}
+
+	if err := pool.SubmitWait(context.Background(), blockingTask(100, release)); err != ErrPoolNotStarted {
+		t.Errorf("Expected ErrPoolNotStarted after Stop(), got %v", err)
+	}
+}
+
+func TestWorkerPool_ShutdownDrainsQueue(t *testing.T) {
+	pool := NewWorkerPool(2, 20)
+	pool.Start()
+
+	var mu sync.Mutex
+	processed := 0
+	for i := 0; i < 20; i++ {
+		pool.Submit(Task{
+			ID: i,
+			Handler: func(payload interface{}) (interface{}, error) {
+				time.Sleep(5 * time.Millisecond)
+				mu.Lock()
+				processed++
+				mu.Unlock()
+				return nil, nil
+			},
+		})
+	}
+
+	pending, err := pool.Shutdown(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(pending) != 0 {
+		t.Errorf("Expected no pending tasks, got %d", len(pending))
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if processed != 20 {
+		t.Errorf("Expected 20 processed tasks, got %d", processed)
+	}
+	if pool.IsRunning() {
+		t.Error("Pool should not be running after Shutdown()")
+	}
+}
+
+func TestWorkerPool_ShutdownDeadline(t *testing.T) {
+	pool := NewWorkerPool(1, 10)
+	pool.Start()
+
+	for i := 0; i < 5; i++ {
+		pool.Submit(Task{
+			ID: i,
+			ContextHandler: func(ctx context.Context, payload interface{}) (interface{}, error) {
+				select {
+				case <-time.After(100 * time.Millisecond):
+				case <-ctx.Done():
+				}
+				return nil, nil
+			},
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+
+	pending, err := pool.Shutdown(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if len(pending) == 0 {
+		t.Error("Expected pending tasks after deadline, got none")
+	}
+	if err := pool.Wait(context.Background()); err != nil {
+		t.Fatalf("Expected Wait to return nil, got %v", err)
+	}
+
+	metrics := pool.GetMetrics()
+	if int(metrics.TasksProcessed)+len(pending) != 5 {
+		t.Errorf("Expected processed + pending = 5, got %d + %d", metrics.TasksProcessed, len(pending))
+	}
+}
+
+func TestWorkerPool_ShutdownDeadlineIgnoredContext(t *testing.T) {
+	pool := NewWorkerPool(1, 10)
+	pool.Start()
+
+	// Handlers legados não recebem o contexto e não podem ser interrompidos
+	release := make(chan struct{})
+	pool.Submit(blockingTask(1, release))
+	pool.Submit(blockingTask(2, release))
+	time.Sleep(20 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	pending, err := pool.Shutdown(ctx)
+	elapsed := time.Since(start)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed > 500*time.Millisecond {
+		t.Errorf("Expected Shutdown to return at its deadline, took %v", elapsed)
+	}
+	if len(pending) != 1 || pending[0].ID != 2 {
+		t.Errorf("Expected task 2 pending, got %v", pending)
+	}
+
+	// O handler abandonado termina em segundo plano
+	waitCtx, waitCancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer waitCancel()
+	if err := pool.Wait(waitCtx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected Wait to time out while the handler runs, got %v", err)
+	}
+
+	close(release)
+	if err := pool.Wait(context.Background()); err != nil {
+		t.Errorf("Expected Wait to return nil, got %v", err)
+	}
+	if processed := pool.GetMetrics().TasksProcessed; processed != 1 {
+		t.Errorf("Expected 1 processed task, got %d", processed)
+	}
+}
+
+func TestWorkerPool_SubmitAfterShutdown(t *testing.T) {
+	pool := NewWorkerPool(1, 10)
+	pool.Start()
+
+	release := make(chan struct{})
+	pool.Submit(blockingTask(1, release))
+
+	done := make(chan struct{})
+	go func() {
+		pool.Shutdown(context.Background())
+		close(done)
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	err := pool.Submit(blockingTask(2, release))
+	if err != ErrPoolStopped {
+		t.Errorf("Expected ErrPoolStopped during Shutdown, got %v", err)
+	}
+
+	close(release)
+	<-done
+}
+
+func TestWorkerPool_ShutdownWithoutStart(t *testing.T) {
+	pool := NewWorkerPool(1, 10)
+
+	pending, err := pool.Shutdown(context.Background())
+	if err != nil || pending != nil {
+		t.Errorf("Expected (nil, nil), got (%v, %v)", pending, err)
+	}
+}
+
+func TestWorkerPool_TaskTimeoutEnforced(t *testing.T) {
+	pool := NewWorkerPool(1, 10)
+	pool.Start()
+
+	release := make(chan struct{})
+	defer pool.Stop()
+	defer close(release)
+
+	// Handler que ignora o contexto
+	task := blockingTask(1, release)
+	task.Timeout = 20 * time.Millisecond
+	task.Error = make(chan error, 1)
+	pool.Submit(task)
+
+	select {
+	case err := <-task.Error:
+		if !errors.Is(err, ErrTaskTimeout) {
+			t.Errorf("Expected ErrTaskTimeout, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Pool did not enforce task timeout")
+	}
+
+	metrics := pool.GetMetrics()
+	if metrics.TasksTimedOut != 1 {
+		t.Errorf("Expected 1 timed out task, got %d", metrics.TasksTimedOut)
+	}
+	if metrics.TasksFailed != 1 {
+		t.Errorf("Expected 1 failed task, got %d", metrics.TasksFailed)
+	}
+}
+
+func TestWorkerPool_TaskDeadline(t *testing.T) {
+	pool := NewWorkerPool(1, 10)
+	pool.Start()
+	defer pool.Stop()
+
+	task := Task{
+		ID:       1,
+		Deadline: time.Now().Add(20 * time.Millisecond),
+		Timeout:  time.Hour,
+		ContextHandler: func(ctx context.Context, payload interface{}) (interface{}, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+		Error: make(chan error, 1),
+	}
+	pool.Submit(task)
+
+	select {
+	case err := <-task.Error:
+		if !errors.Is(err, ErrTaskTimeout) {
+			t.Errorf("Expected ErrTaskTimeout, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Pool did not enforce task deadline")
+	}
+}
+
+func TestWorkerPool_TaskTimeoutCountsAttempts(t *testing.T) {
+	pool := NewWorkerPool(1, 10)
+
+	attempts := make(chan int, 1)
+	pool.AddHooks(Hooks[interface{}, interface{}]{
+		AfterExecute: func(ctx context.Context, event TaskEvent[interface{}, interface{}]) {
+			attempts <- event.Attempts
+		},
+	})
+	pool.Start()
+
+	release := make(chan struct{})
+	defer pool.Stop()
+	defer close(release)
+
+	// Duas falhas rápidas e uma terceira tentativa que ignora o contexto
+	calls := 0
+	task := Task{
+		ID:      1,
+		Timeout: 100 * time.Millisecond,
+		Retry:   &RetryPolicy{MaxAttempts: 5},
+		Handler: func(payload interface{}) (interface{}, error) {
+			calls++
+			if calls < 3 {
+				return nil, errors.New("falha transitória")
+			}
+			<-release
+			return nil, nil
+		},
+		Error: make(chan error, 1),
+	}
+	pool.Submit(task)
+
+	select {
+	case err := <-task.Error:
+		if !errors.Is(err, ErrTaskTimeout) {
+			t.Errorf("Expected ErrTaskTimeout, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Pool did not enforce task timeout")
+	}
+
+	if got := <-attempts; got != 3 {
+		t.Errorf("Expected 3 attempts, got %d", got)
+	}
+}
+
+func TestWorkerPool_WaitAbandonedHandler(t *testing.T) {
+	pool := NewWorkerPool(1, 10)
+	pool.Start()
+
+	release := make(chan struct{})
+	finished := make(chan struct{})
+	task := Task{
+		ID:      1,
+		Timeout: 20 * time.Millisecond,
+		Handler: func(payload interface{}) (interface{}, error) {
+			<-release
+			close(finished)
+			return nil, nil
+		},
+		Error: make(chan error, 1),
+	}
+	pool.Submit(task)
+
+	if err := <-task.Error; !errors.Is(err, ErrTaskTimeout) {
+		t.Fatalf("Expected ErrTaskTimeout, got %v", err)
+	}
+	pool.Stop()
+
+	// O handler abandonado ainda roda após Stop
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := pool.Wait(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Expected Wait to time out while the handler runs, got %v", err)
+	}
+
+	close(release)
+	if err := pool.Wait(context.Background()); err != nil {
+		t.Errorf("Expected nil from Wait, got %v", err)
+	}
+	select {
+	case <-finished:
+	default:
+		t.Error("Expected Wait to return only after the abandoned handler finished")
+	}
+}
+
+func TestWorkerPool_TaskWithinTimeout(t *testing.T) {
+	pool := NewWorkerPool(1, 10)
+	pool.Start()
+	defer pool.Stop()
+
+	task := Task{
+		ID:      1,
+		Timeout: time.Second,
+		Handler: func(payload interface{}) (interface{}, error) {
+			return "ok", nil
+		},
+		Result: make(chan Result, 1),
+	}
+	pool.Submit(task)
+
+	select {
+	case result := <-task.Result:
+		if result.Output != "ok" {
+			t.Errorf("Expected output 'ok', got %v", result.Output)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timeout waiting for result")
+	}
+
+	if metrics := pool.GetMetrics(); metrics.TasksTimedOut != 0 {
+		t.Errorf("Expected 0 timed out tasks, got %d", metrics.TasksTimedOut)
+	}
+}
+
+func TestWorkerPool_PanicRecovery(t *testing.T) {
+	pool := NewWorkerPool(1, 10)
+	pool.Start()
+	defer pool.Stop()
+
+	panicking := Task{
+		ID: 1,
+		Handler: func(payload interface{}) (interface{}, error) {
+			_ = payload.(string) // payload é int: type assertion falha
+			return nil, nil
+		},
+		Payload: 42,
+		Retry:   &RetryPolicy{MaxAttempts: 3},
+		Error:   make(chan error, 1),
+	}
+	pool.Submit(panicking)
+
+	select {
+	case err := <-panicking.Error:
+		if !errors.Is(err, ErrTaskPanicked) {
+			t.Fatalf("Expected ErrTaskPanicked, got %v", err)
+		}
+		var panicErr *PanicError
+		if !errors.As(err, &panicErr) {
+			t.Fatalf("Expected *PanicError, got %T", err)
+		}
+		if panicErr.TaskID != 1 {
+			t.Errorf("Expected TaskID 1, got %d", panicErr.TaskID)
+		}
+		if len(panicErr.Stack) == 0 {
+			t.Error("Expected stack trace, got none")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timeout waiting for panic error")
+	}
+
+	// O worker deve continuar processando tarefas
+	next := Task{
+		ID: 2,
+		Handler: func(payload interface{}) (interface{}, error) {
+			return "ok", nil
+		},
+		Result: make(chan Result, 1),
+	}
+	pool.Submit(next)
+
+	select {
+	case <-next.Result:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Worker did not survive the panic")
+	}
+
+	metrics := pool.GetMetrics()
+	if metrics.TasksPanicked != 1 {
+		t.Errorf("Expected 1 panicked task, got %d", metrics.TasksPanicked)
+	}
+	if metrics.TasksRetried != 0 {
+		t.Errorf("Expected panics not to be retried, got %d retried tasks", metrics.TasksRetried)
+	}
+}
+
+func TestWorkerPool_PanicWithTimeout(t *testing.T) {
+	pool := NewWorkerPool(1, 10)
+	pool.Start()
+	defer pool.Stop()
+
+	task := Task{
+		ID:      1,
+		Timeout: time.Second,
+		Handler: func(payload interface{}) (interface{}, error) {
+			panic("boom")
+		},
+		Error: make(chan error, 1),
+	}
+	pool.Submit(task)
+
+	select {
+	case err := <-task.Error:
+		if !errors.Is(err, ErrTaskPanicked) {
+			t.Errorf("Expected ErrTaskPanicked, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timeout waiting for panic error")
+	}
+}
+
+func TestWorkerPool_ResizeGrow(t *testing.T) {
+	pool := NewWorkerPool(1, 10)
+	pool.Start()
+	defer pool.Stop()
+
+	release := make(chan struct{})
+	defer close(release)
+
+	var running int32
+	started := make(chan struct{}, 4)
+	for i := 0; i < 4; i++ {
+		pool.Submit(Task{
+			ID: i,
+			Handler: func(payload interface{}) (interface{}, error) {
+				atomic.AddInt32(&running, 1)
+				started <- struct{}{}
+				<-release
+				return nil, nil
+			},
+		})
+	}
+
+	<-started
+	pool.Resize(4)
+	if pool.GetWorkerCount() != 4 {
+		t.Errorf("Expected 4 workers, got %d", pool.GetWorkerCount())
+	}
+
+	for i := 0; i < 3; i++ {
+		select {
+		case <-started:
+		case <-time.After(2 * time.Second):
+			t.Fatalf("Expected 4 concurrent tasks after Resize, got %d", atomic.LoadInt32(&running))
+		}
+	}
+}
+
+func TestWorkerPool_ResizeShrinkKeepsTasks(t *testing.T) {
+	pool := NewWorkerPool(4, 50)
+	pool.Start()
+
+	var processed int32
+	var current, peak int32
+	for i := 0; i < 40; i++ {
+		pool.Submit(Task{
+			ID: i,
+			Handler: func(payload interface{}) (interface{}, error) {
+				n := atomic.AddInt32(&current, 1)
+				for {
+					p := atomic.LoadInt32(&peak)
+					if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+						break
+					}
+				}
+				time.Sleep(2 * time.Millisecond)
+				atomic.AddInt32(&current, -1)
+				atomic.AddInt32(&processed, 1)
+				return nil, nil
+			},
+		})
+	}
+
+	time.Sleep(5 * time.Millisecond)
+	pool.Resize(1)
+
+	// Após os workers aposentados terminarem a tarefa atual, só um worker deve restar
+	deadline := time.Now().Add(2 * time.Second)
+	for pool.GetWorkerCount() != 1 {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected 1 live worker, got %d", pool.GetWorkerCount())
+		}
+		time.Sleep(time.Millisecond)
+	}
+	atomic.StoreInt32(&peak, 0)
+
+	pending, err := pool.Shutdown(context.Background())
+	if err != nil || len(pending) != 0 {
+		t.Fatalf("Expected clean shutdown, got %d pending, err %v", len(pending), err)
+	}
+	if got := atomic.LoadInt32(&processed); got != 40 {
+		t.Errorf("Expected 40 processed tasks, got %d", got)
+	}
+	if got := atomic.LoadInt32(&peak); got > 1 {
+		t.Errorf("Expected at most 1 concurrent task after shrink, got %d", got)
+	}
+}
+
+func TestWorkerPool_WorkerCountIsLive(t *testing.T) {
+	pool := NewWorkerPool(2, 10)
+	pool.Start()
+
+	release := make(chan struct{})
+	started := make(chan struct{}, 2)
+	for i := 0; i < 2; i++ {
+		pool.Submit(Task{
+			ID: i,
+			Handler: func(payload interface{}) (interface{}, error) {
+				started <- struct{}{}
+				<-release
+				return nil, nil
+			},
+		})
+	}
+	<-started
+	<-started
+
+	// O worker aposentado continua contando até terminar a tarefa atual
+	pool.Resize(1)
+	if got := pool.GetWorkerCount(); got != 2 {
+		t.Errorf("Expected 2 live workers while one retires, got %d", got)
+	}
+	close(release)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for pool.GetWorkerCount() != 1 {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected 1 live worker after retirement, got %d", pool.GetWorkerCount())
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	pool.Stop()
+	if got := pool.GetWorkerCount(); got != 0 {
+		t.Errorf("Expected 0 workers after Stop, got %d", got)
+	}
+}
+
+func TestWorkerPool_ResizeBeforeStart(t *testing.T) {
+	pool := NewWorkerPool(2, 10)
+	pool.Resize(5)
+	if pool.GetWorkerCount() != 5 {
+		t.Errorf("Expected 5 workers, got %d", pool.GetWorkerCount())
+	}
+
+	pool.Resize(0)
+	if pool.GetWorkerCount() != 1 {
+		t.Errorf("Expected minimum of 1 worker, got %d", pool.GetWorkerCount())
+	}
+}
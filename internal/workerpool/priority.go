@@ -0,0 +1,78 @@
+package workerpool
+
+import (
+	"fmt"
+	"time"
+)
+
+// Priority define a prioridade de uma tarefa na fila.
+// O valor zero é PriorityNormal.
+type Priority int
+
+const (
+	PriorityLow Priority = iota - 1
+	PriorityNormal
+	PriorityHigh
+)
+
+// priorityLevels é o número de níveis de prioridade (uma fila por nível)
+const priorityLevels = int(PriorityHigh-PriorityLow) + 1
+
+// DefaultAgingInterval é o tempo de espera que eleva em um nível a prioridade
+// efetiva de uma tarefa, evitando que tarefas de baixa prioridade fiquem paradas
+const DefaultAgingInterval = time.Second
+
+// String retorna o nome da prioridade
+func (p Priority) String() string {
+	switch p {
+	case PriorityLow:
+		return "low"
+	case PriorityNormal:
+		return "normal"
+	case PriorityHigh:
+		return "high"
+	default:
+		return fmt.Sprintf("Priority(%d)", int(p))
+	}
+}
+
+// level converte a prioridade no índice da fila, limitando valores fora da faixa
+func (p Priority) level() int {
+	if p < PriorityLow {
+		p = PriorityLow
+	}
+	if p > PriorityHigh {
+		p = PriorityHigh
+	}
+	return int(p - PriorityLow)
+}
+
+// queuedTask é uma tarefa na fila, junto com o instante em que foi enfileirada
+type queuedTask[In, Out any] struct {
+	task       TypedTask[In, Out]
+	enqueuedAt time.Time
+	// submitted é fechado quando OnSubmit termina (nil sem hooks OnSubmit)
+	submitted chan struct{}
+}
+
+// nextLevel escolhe o nível cuja tarefa deve ser entregue primeiro.
+// A prioridade efetiva é o nível somado a um ponto por agingInterval de espera;
+// em caso de empate vence o nível mais alto. Retorna -1 se não houver tarefas.
+func nextLevel[In, Out any](heads *[priorityLevels]*queuedTask[In, Out], agingInterval time.Duration, now time.Time) int {
+	best, bestScore := -1, 0
+	for level := priorityLevels - 1; level >= 0; level-- {
+		head := heads[level]
+		if head == nil {
+			continue
+		}
+
+		score := level
+		if agingInterval > 0 {
+			score += int(now.Sub(head.enqueuedAt) / agingInterval)
+		}
+		if best < 0 || score > bestScore {
+			best, bestScore = level, score
+		}
+	}
+	return best
+}
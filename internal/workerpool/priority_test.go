@@ -0,0 +1,171 @@
+package workerpool
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPriority_Level(t *testing.T) {
+	tests := []struct {
+		priority Priority
+		expected int
+	}{
+		{PriorityLow, 0},
+		{PriorityNormal, 1},
+		{PriorityHigh, 2},
+		{Priority(-10), 0},
+		{Priority(10), 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.priority.String(), func(t *testing.T) {
+			if got := tt.priority.level(); got != tt.expected {
+				t.Errorf("Expected level %d, got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestNextLevel_Aging(t *testing.T) {
+	now := time.Now()
+	var heads [priorityLevels]*queuedTask[int, int]
+
+	if got := nextLevel(&heads, time.Second, now); got != -1 {
+		t.Errorf("Expected -1 with no tasks, got %d", got)
+	}
+
+	heads[PriorityLow.level()] = &queuedTask[int, int]{enqueuedAt: now.Add(-1500 * time.Millisecond)}
+	heads[PriorityHigh.level()] = &queuedTask[int, int]{enqueuedAt: now}
+
+	// Low esperou 1 intervalo: efetiva 1, abaixo de high (2)
+	if got := nextLevel(&heads, time.Second, now); got != PriorityHigh.level() {
+		t.Errorf("Expected high priority, got level %d", got)
+	}
+
+	// Low esperou 3 intervalos: efetiva 3, acima de high
+	heads[PriorityLow.level()].enqueuedAt = now.Add(-3 * time.Second)
+	if got := nextLevel(&heads, time.Second, now); got != PriorityLow.level() {
+		t.Errorf("Expected aged low priority, got level %d", got)
+	}
+
+	// Sem envelhecimento, vale apenas o nível
+	if got := nextLevel(&heads, 0, now); got != PriorityHigh.level() {
+		t.Errorf("Expected high priority without aging, got level %d", got)
+	}
+}
+
+func TestWorkerPool_PriorityOrder(t *testing.T) {
+	pool := NewWorkerPool(1, 10)
+	pool.Start()
+	defer pool.Stop()
+
+	release := make(chan struct{})
+	pool.Submit(blockingTask(0, release))
+	time.Sleep(20 * time.Millisecond) // worker ocupado com a tarefa 0
+
+	var mu sync.Mutex
+	var order []Priority
+	results := make(chan Result, 6)
+
+	priorities := []Priority{PriorityLow, PriorityNormal, PriorityHigh, PriorityLow, PriorityHigh, PriorityNormal}
+	for i, p := range priorities {
+		priority := p
+		pool.Submit(Task{
+			ID:       i + 1,
+			Priority: priority,
+			Handler: func(payload interface{}) (interface{}, error) {
+				mu.Lock()
+				order = append(order, priority)
+				mu.Unlock()
+				return nil, nil
+			},
+			Result: results,
+		})
+	}
+
+	depths := pool.QueueDepthByPriority()
+	for _, p := range []Priority{PriorityLow, PriorityNormal, PriorityHigh} {
+		if depths[p] != 2 {
+			t.Errorf("Expected depth 2 for %s, got %d", p, depths[p])
+		}
+	}
+	if pool.QueueDepth() != 6 {
+		t.Errorf("Expected total depth 6, got %d", pool.QueueDepth())
+	}
+
+	close(release)
+	for i := 0; i < len(priorities); i++ {
+		select {
+		case <-results:
+		case <-time.After(2 * time.Second):
+			t.Fatal("Timeout waiting for results")
+		}
+	}
+
+	expected := []Priority{PriorityHigh, PriorityHigh, PriorityNormal, PriorityNormal, PriorityLow, PriorityLow}
+	mu.Lock()
+	defer mu.Unlock()
+	for i := range expected {
+		if order[i] != expected[i] {
+			t.Fatalf("Expected order %v, got %v", expected, order)
+		}
+	}
+
+	if pool.QueueDepth() != 0 {
+		t.Errorf("Expected empty queue, got depth %d", pool.QueueDepth())
+	}
+}
+
+func TestWorkerPool_PriorityAgingPreventsStarvation(t *testing.T) {
+	pool := NewWorkerPool(1, 10)
+	pool.agingInterval = 10 * time.Millisecond
+	pool.Start()
+	defer pool.Stop()
+
+	release := make(chan struct{})
+	pool.Submit(blockingTask(0, release))
+	time.Sleep(20 * time.Millisecond)
+
+	var mu sync.Mutex
+	var order []Priority
+	results := make(chan Result, 6)
+
+	submit := func(id int, priority Priority) {
+		pool.Submit(Task{
+			ID:       id,
+			Priority: priority,
+			Handler: func(payload interface{}) (interface{}, error) {
+				mu.Lock()
+				order = append(order, priority)
+				mu.Unlock()
+				return nil, nil
+			},
+			Result: results,
+		})
+	}
+
+	submit(1, PriorityLow)
+
+	// Deixa a tarefa de baixa prioridade envelhecer
+	time.Sleep(50 * time.Millisecond)
+
+	for i := 0; i < 5; i++ {
+		submit(i+2, PriorityHigh)
+	}
+
+	close(release)
+	for i := 0; i < 6; i++ {
+		select {
+		case <-results:
+		case <-time.After(2 * time.Second):
+			t.Fatal("Timeout waiting for results")
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if order[0] != PriorityLow {
+		t.Errorf("Expected aged low priority task to run first, got order %v", order)
+	}
+}
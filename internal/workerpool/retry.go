@@ -0,0 +1,79 @@
+package workerpool
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy define como uma tarefa que falhou é executada novamente
+type RetryPolicy struct {
+	// MaxAttempts é o total de tentativas, incluindo a primeira
+	MaxAttempts int
+	// InitialBackoff é a espera antes da segunda tentativa
+	InitialBackoff time.Duration
+	// MaxBackoff limita o crescimento da espera (zero = sem limite)
+	MaxBackoff time.Duration
+	// Multiplier é o fator de crescimento da espera entre tentativas (padrão: 2)
+	Multiplier float64
+	// Jitter é a fração aleatória aplicada à espera, entre 0 e 1
+	Jitter float64
+	// Retryable decide se um erro deve gerar nova tentativa (nil = todos os erros)
+	Retryable func(error) bool
+}
+
+// DefaultRetryPolicy retorna uma política com 3 tentativas e backoff exponencial
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 50 * time.Millisecond,
+		MaxBackoff:     2 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+// shouldRetry verifica se uma nova tentativa deve ser feita após a tentativa attempt
+func (p *RetryPolicy) shouldRetry(err error, attempt int) bool {
+	if p == nil || err == nil || attempt >= p.MaxAttempts {
+		return false
+	}
+	if p.Retryable != nil {
+		return p.Retryable(err)
+	}
+	return true
+}
+
+// backoff calcula a espera antes da próxima tentativa, após a tentativa attempt
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+
+	delay := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+
+	if p.Jitter > 0 {
+		jitter := math.Min(p.Jitter, 1)
+		delay += delay * jitter * (rand.Float64()*2 - 1)
+	}
+
+	return time.Duration(delay)
+}
+
+// wait aguarda o backoff da tentativa ou o fim do contexto
+func (p *RetryPolicy) wait(ctx context.Context, attempt int) error {
+	timer := time.NewTimer(p.backoff(attempt))
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,179 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := &RetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: 10 * time.Millisecond,
+		MaxBackoff:     50 * time.Millisecond,
+		Multiplier:     2,
+	}
+
+	expected := []time.Duration{
+		10 * time.Millisecond,
+		20 * time.Millisecond,
+		40 * time.Millisecond,
+		50 * time.Millisecond, // limitado por MaxBackoff
+	}
+
+	for i, want := range expected {
+		if got := policy.backoff(i + 1); got != want {
+			t.Errorf("Attempt %d: expected backoff %v, got %v", i+1, want, got)
+		}
+	}
+}
+
+func TestRetryPolicy_Jitter(t *testing.T) {
+	policy := &RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 100 * time.Millisecond,
+		Jitter:         0.5,
+	}
+
+	for i := 0; i < 100; i++ {
+		got := policy.backoff(1)
+		if got < 50*time.Millisecond || got > 150*time.Millisecond {
+			t.Fatalf("Expected backoff within 50ms-150ms, got %v", got)
+		}
+	}
+}
+
+func TestRetryPolicy_ShouldRetry(t *testing.T) {
+	errTransient := errors.New("transient")
+	errPermanent := errors.New("permanent")
+
+	policy := &RetryPolicy{
+		MaxAttempts: 3,
+		Retryable: func(err error) bool {
+			return errors.Is(err, errTransient)
+		},
+	}
+
+	tests := []struct {
+		name    string
+		policy  *RetryPolicy
+		err     error
+		attempt int
+		want    bool
+	}{
+		{"Nil policy", nil, errTransient, 1, false},
+		{"No error", policy, nil, 1, false},
+		{"Retryable error", policy, errTransient, 1, true},
+		{"Permanent error", policy, errPermanent, 1, false},
+		{"Attempts exhausted", policy, errTransient, 3, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.shouldRetry(tt.err, tt.attempt); got != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestWorkerPool_RetrySucceeds(t *testing.T) {
+	pool := NewWorkerPool(1, 10)
+	pool.Start()
+	defer pool.Stop()
+
+	var calls int32
+	task := Task{
+		ID: 1,
+		Handler: func(payload interface{}) (interface{}, error) {
+			if atomic.AddInt32(&calls, 1) < 3 {
+				return nil, errors.New("database is locked")
+			}
+			return "ok", nil
+		},
+		Retry:  &RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Millisecond},
+		Result: make(chan Result, 1),
+		Error:  make(chan error, 1),
+	}
+	pool.Submit(task)
+
+	select {
+	case result := <-task.Result:
+		if result.Attempts != 3 {
+			t.Errorf("Expected 3 attempts, got %d", result.Attempts)
+		}
+	case err := <-task.Error:
+		t.Fatalf("Expected success after retries, got %v", err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timeout waiting for result")
+	}
+
+	metrics := pool.GetMetrics()
+	if metrics.TasksRetried != 1 {
+		t.Errorf("Expected 1 retried task, got %d", metrics.TasksRetried)
+	}
+	if metrics.TotalRetries != 2 {
+		t.Errorf("Expected 2 retries, got %d", metrics.TotalRetries)
+	}
+	if metrics.TasksFailed != 0 {
+		t.Errorf("Expected 0 failed tasks, got %d", metrics.TasksFailed)
+	}
+}
+
+func TestWorkerPool_RetryExhausted(t *testing.T) {
+	pool := NewWorkerPool(1, 10)
+	pool.Start()
+	defer pool.Stop()
+
+	var calls int32
+	task := Task{
+		ID: 1,
+		Handler: func(payload interface{}) (interface{}, error) {
+			atomic.AddInt32(&calls, 1)
+			return nil, errors.New("database is locked")
+		},
+		Retry: &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond},
+		Error: make(chan error, 1),
+	}
+	pool.Submit(task)
+
+	select {
+	case <-task.Error:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timeout waiting for error")
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Errorf("Expected 3 calls, got %d", got)
+	}
+}
+
+func TestWorkerPool_RetryStopsOnCancel(t *testing.T) {
+	pool := NewWorkerPool(1, 10)
+	pool.Start()
+	defer pool.Stop()
+
+	task := Task{
+		ID:      1,
+		Timeout: 30 * time.Millisecond,
+		ContextHandler: func(ctx context.Context, payload interface{}) (interface{}, error) {
+			return nil, errors.New("database is locked")
+		},
+		Retry: &RetryPolicy{MaxAttempts: 10, InitialBackoff: time.Second},
+		Error: make(chan error, 1),
+	}
+
+	start := time.Now()
+	pool.Submit(task)
+
+	select {
+	case <-task.Error:
+		if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+			t.Errorf("Expected backoff to be interrupted by timeout, took %v", elapsed)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timeout waiting for error")
+	}
+}
@@ -0,0 +1,299 @@
+package xlsxreader
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/seu-usuario/worker-pool-csv-processor/internal/csvreader"
+	"github.com/seu-usuario/worker-pool-csv-processor/internal/models"
+)
+
+// Reader implementa models.RecordReader
+var _ models.RecordReader = (*Reader)(nil)
+
+// Reader lê registros de uma planilha XLSX. A primeira linha preenchida é o
+// cabeçalho e as colunas são localizadas pelo nome, como no leitor CSV.
+type Reader struct {
+	filePath    string
+	sheet       string
+	aliases     map[string]string
+	dateLayouts []string
+}
+
+// Options configura um Reader criado com NewReaderWithOptions
+type Options struct {
+	// Sheet é o nome da planilha a ler (vazio = primeira planilha)
+	Sheet string
+	// Aliases mapeia nomes alternativos de coluna, como em csvreader.Options
+	Aliases map[string]string
+	// DateLayouts são os formatos aceitos em datas digitadas como texto
+	// (vazio = csvreader.DefaultDateLayouts). Células de data nativas não dependem deles.
+	DateLayouts []string
+}
+
+// NewReader cria um leitor para a primeira planilha do arquivo
+func NewReader(filePath string) *Reader {
+	return NewReaderWithOptions(filePath, Options{})
+}
+
+// NewReaderWithOptions cria um leitor a partir de Options
+func NewReaderWithOptions(filePath string, opts Options) *Reader {
+	if len(opts.DateLayouts) == 0 {
+		opts.DateLayouts = csvreader.DefaultDateLayouts
+	}
+	return &Reader{
+		filePath:    filePath,
+		sheet:       opts.Sheet,
+		aliases:     opts.Aliases,
+		dateLayouts: opts.DateLayouts,
+	}
+}
+
+// ReadAll lê toda a planilha e retorna os registros
+func (r *Reader) ReadAll() ([]*models.Record, []error, error) {
+	return models.ReadAll(r)
+}
+
+// Open abre a planilha para leitura linha a linha, consumindo o cabeçalho.
+// RowNumber é o número da linha na planilha.
+func (r *Reader) Open() (models.RecordStream, error) {
+	wb, err := openWorkbook(r.filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	sheet, err := wb.sheet(r.sheet)
+	if err != nil {
+		wb.Close()
+		return nil, err
+	}
+	rows, err := wb.openRows(sheet)
+	if err != nil {
+		wb.Close()
+		return nil, err
+	}
+
+	s := &Stream{reader: r, workbook: wb, sheet: sheet.name, rows: rows}
+	if err := s.readHeader(); err != nil {
+		s.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Stream lê os registros de uma planilha
+type Stream struct {
+	reader   *Reader
+	workbook *workbook
+	sheet    string
+	rows     *rowDecoder
+	columns  map[string]int
+	lastRow  int
+	err      error
+}
+
+// readHeader lê a primeira linha preenchida e localiza as colunas
+func (s *Stream) readHeader() error {
+	for {
+		row, err := s.rows.next()
+		if err == io.EOF {
+			return fmt.Errorf("planilha %s vazia", s.sheet)
+		}
+		if err != nil {
+			return err
+		}
+
+		cells, err := s.cells(row)
+		if err != nil {
+			return err
+		}
+		if len(cells) == 0 {
+			continue
+		}
+
+		header := make([]string, len(cells))
+		for i, c := range cells {
+			header[i] = c.String()
+		}
+		if s.columns, err = csvreader.MapHeader(header, s.reader.aliases); err != nil {
+			return fmt.Errorf("planilha %s: %w", s.sheet, err)
+		}
+		return nil
+	}
+}
+
+// Next retorna o próximo registro, ignorando linhas vazias.
+// Ao fim da planilha retorna io.EOF.
+func (s *Stream) Next() (*models.Record, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	for {
+		row, err := s.rows.next()
+		if err != nil {
+			s.err = err
+			return nil, err
+		}
+
+		cells, err := s.cells(row)
+		if err != nil {
+			s.err = err
+			return nil, err
+		}
+		if len(cells) == 0 {
+			continue
+		}
+		record, err := s.parseRow(cells, s.lastRow)
+		return models.WithSource(record, err, s.reader.filePath)
+	}
+}
+
+// Close fecha o arquivo
+func (s *Stream) Close() error {
+	s.rows.Close()
+	return s.workbook.Close()
+}
+
+// cells converte as células da linha, posicionadas pela coluna de cada uma.
+// Retorna nil se a linha não tiver nenhum valor.
+func (s *Stream) cells(row rawRow) ([]cell, error) {
+	// Linhas sem o atributo r seguem a anterior
+	if row.Number == 0 {
+		row.Number = s.lastRow + 1
+	}
+	s.lastRow = row.Number
+
+	var cells []cell
+	empty := true
+	for i, raw := range row.Cells {
+		index := i
+		if raw.Ref != "" {
+			if _, index = splitRef(raw.Ref); index < 0 {
+				return nil, fmt.Errorf("%w: referência de célula inválida %q", ErrInvalidFile, raw.Ref)
+			}
+		}
+
+		value, err := s.workbook.convert(raw)
+		if err != nil {
+			return nil, err
+		}
+		if value.kind == cellEmpty {
+			continue
+		}
+
+		for len(cells) <= index {
+			cells = append(cells, cell{})
+		}
+		cells[index] = value
+		empty = false
+	}
+
+	if empty {
+		return nil, nil
+	}
+	return cells, nil
+}
+
+// parseRow converte as células de uma linha em um Record (veja
+// models.ParseRecord). Os erros indicam também a planilha e a coluna da célula.
+func (s *Stream) parseRow(cells []cell, rowNumber int) (*models.Record, error) {
+	record, err := models.ParseRecord(rowFields{stream: s, cells: cells}, rowNumber,
+		"célula de data, "+strings.Join(s.reader.dateLayouts, ", "))
+	for _, validationErr := range models.ValidationErrorsOf(err) {
+		validationErr.Sheet = s.sheet
+		validationErr.Column = columnName(s.columns[validationErr.Field])
+	}
+	return record, err
+}
+
+// rowFields implementa models.Fields sobre as células de uma linha,
+// aceitando células nativas (número, booleano, data) ou texto
+type rowFields struct {
+	stream *Stream
+	cells  []cell
+}
+
+// cell retorna a célula do campo, ou uma célula vazia se a linha for mais curta
+func (f rowFields) cell(field string) cell {
+	if index := f.stream.columns[field]; index < len(f.cells) {
+		return f.cells[index]
+	}
+	return cell{}
+}
+
+func (f rowFields) Text(field string) string {
+	return f.cell(field).String()
+}
+
+func (f rowFields) Int(field string) (int, bool) {
+	return integerValue(f.cell(field))
+}
+
+func (f rowFields) Float(field string) (float64, bool) {
+	return numberValue(f.cell(field))
+}
+
+func (f rowFields) Bool(field string) (bool, bool) {
+	return boolValue(f.cell(field))
+}
+
+func (f rowFields) Date(field string) (time.Time, bool) {
+	return f.stream.dateValue(f.cell(field))
+}
+
+// numberValue lê um número de uma célula numérica ou de texto
+func numberValue(c cell) (float64, bool) {
+	switch c.kind {
+	case cellNumber:
+		return c.number, true
+	case cellText:
+		number, err := strconv.ParseFloat(strings.TrimSpace(c.text), 64)
+		return number, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// integerValue lê um número inteiro; números com parte fracionária são rejeitados
+func integerValue(c cell) (int, bool) {
+	number, ok := numberValue(c)
+	if !ok || number != math.Trunc(number) {
+		return 0, false
+	}
+	return int(number), true
+}
+
+// boolValue lê um booleano de uma célula lógica, numérica (0/1) ou de texto
+func boolValue(c cell) (bool, bool) {
+	switch c.kind {
+	case cellBool:
+		return c.number != 0, true
+	case cellNumber:
+		return c.number != 0, c.number == 0 || c.number == 1
+	case cellText:
+		b, err := strconv.ParseBool(strings.TrimSpace(c.text))
+		return b, err == nil
+	default:
+		return false, false
+	}
+}
+
+// dateValue lê uma data de uma célula de data nativa ou de texto
+func (s *Stream) dateValue(c cell) (time.Time, bool) {
+	switch c.kind {
+	case cellDate:
+		return c.date, true
+	case cellText:
+		for _, layout := range s.reader.dateLayouts {
+			if date, err := time.Parse(layout, strings.TrimSpace(c.text)); err == nil {
+				return date, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
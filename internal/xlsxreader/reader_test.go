@@ -0,0 +1,285 @@
+package xlsxreader
+
+import (
+	"archive/zip"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/seu-usuario/worker-pool-csv-processor/internal/csvreader"
+	"github.com/seu-usuario/worker-pool-csv-processor/internal/models"
+)
+
+const (
+	testWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+  <sheets>
+    <sheet name="Resumo" sheetId="1" r:id="rId1"/>
+    <sheet name="Funcionários" sheetId="2" r:id="rId2"/>
+  </sheets>
+</workbook>`
+
+	testRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+  <Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+  <Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="/xl/worksheets/sheet2.xml"/>
+</Relationships>`
+
+	// Estilo 1 usa o formato embutido 14 (data); estilo 2 usa um formato personalizado de data
+	testStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+  <numFmts count="1"><numFmt numFmtId="164" formatCode="dd/mm/yyyy;@"/></numFmts>
+  <cellXfs count="3"><xf numFmtId="0"/><xf numFmtId="14"/><xf numFmtId="164"/></cellXfs>
+</styleSheet>`
+
+	testSharedStrings = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+  <si><t>nome</t></si>
+  <si><t>email</t></si>
+  <si><t>idade</t></si>
+  <si><t>salario</t></si>
+  <si><t>departamento</t></si>
+  <si><t>ativo</t></si>
+  <si><t>created_at</t></si>
+  <si><r><t>João </t></r><r><t>Silva</t></r></si>
+  <si><t>TI</t></si>
+</sst>`
+
+	testSummarySheet = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData/></worksheet>`
+
+	// A linha 3 está vazia; a linha 5 tem idade fracionária
+	testEmployeesSheet = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+  <sheetData>
+    <row r="1">
+      <c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="C1" t="s"><v>2</v></c>
+      <c r="D1" t="s"><v>3</v></c><c r="E1" t="s"><v>4</v></c><c r="F1" t="s"><v>5</v></c>
+      <c r="H1" t="s"><v>6</v></c>
+    </row>
+    <row r="2">
+      <c r="A2" t="s"><v>7</v></c><c r="B2" t="inlineStr"><is><t>joao@empresa.com</t></is></c>
+      <c r="C2"><v>28</v></c><c r="D2"><v>5500.5</v></c><c r="E2" t="s"><v>8</v></c>
+      <c r="F2" t="b"><v>1</v></c><c r="H2" s="1"><v>45306</v></c>
+    </row>
+    <row r="3"/>
+    <row r="4">
+      <c r="A4" t="inlineStr"><is><t>Maria Santos</t></is></c><c r="B4" t="inlineStr"><is><t>maria@empresa.com</t></is></c>
+      <c r="C4" t="inlineStr"><is><t>32</t></is></c><c r="D4" t="inlineStr"><is><t>6200</t></is></c>
+      <c r="E4" t="inlineStr"><is><t>RH</t></is></c><c r="F4" t="inlineStr"><is><t>false</t></is></c>
+      <c r="H4" t="inlineStr"><is><t>2024-01-16</t></is></c>
+    </row>
+    <row r="5">
+      <c r="A5" t="inlineStr"><is><t>Pedro</t></is></c><c r="B5" t="inlineStr"><is><t>pedro@empresa.com</t></is></c>
+      <c r="C5"><v>45.5</v></c><c r="D5"><v>8500</v></c><c r="E5" t="s"><v>8</v></c>
+      <c r="F5"><v>1</v></c><c r="H5" s="2"><v>45308</v></c>
+    </row>
+  </sheetData>
+</worksheet>`
+)
+
+// createTestXLSX grava um arquivo XLSX mínimo com as partes informadas
+func createTestXLSX(t *testing.T, parts map[string]string) string {
+	t.Helper()
+
+	file, err := os.CreateTemp("", "test_*.xlsx")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	t.Cleanup(func() { os.Remove(file.Name()) })
+
+	archive := zip.NewWriter(file)
+	for name, content := range parts {
+		w, err := archive.Create(name)
+		if err != nil {
+			t.Fatalf("Failed to create zip entry: %v", err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatalf("Failed to write zip entry: %v", err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatalf("Failed to close zip: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		t.Fatalf("Failed to close file: %v", err)
+	}
+	return file.Name()
+}
+
+// testParts retorna as partes de um workbook com as planilhas Resumo e Funcionários
+func testParts() map[string]string {
+	return map[string]string{
+		"xl/workbook.xml":            testWorkbook,
+		"xl/_rels/workbook.xml.rels": testRels,
+		"xl/styles.xml":              testStyles,
+		"xl/sharedStrings.xml":       testSharedStrings,
+		"xl/worksheets/sheet1.xml":   testSummarySheet,
+		"xl/worksheets/sheet2.xml":   testEmployeesSheet,
+	}
+}
+
+func TestReadAll_NativeCellsAndText(t *testing.T) {
+	path := createTestXLSX(t, testParts())
+
+	records, parseErrors, err := NewReaderWithOptions(path, Options{Sheet: "funcionários"}).ReadAll()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(records))
+	}
+
+	rec := records[0]
+	if rec.Name != "João Silva" || rec.Email != "joao@empresa.com" || rec.Age != 28 ||
+		rec.Salary != 5500.5 || rec.Department != "TI" || !rec.IsActive || rec.RowNumber != 2 {
+		t.Errorf("Unexpected first record: %+v", rec)
+	}
+	// 45306 é o número serial de 15/01/2024
+	if expected := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC); !rec.CreatedAt.Equal(expected) {
+		t.Errorf("Expected date %v, got %v", expected, rec.CreatedAt)
+	}
+
+	rec = records[1]
+	if rec.Name != "Maria Santos" || rec.Age != 32 || rec.Salary != 6200 || rec.IsActive || rec.RowNumber != 4 {
+		t.Errorf("Unexpected second record: %+v", rec)
+	}
+
+	if len(parseErrors) != 1 {
+		t.Fatalf("Expected 1 parse error, got %v", parseErrors)
+	}
+	var validationErr *models.ValidationError
+	if !errors.As(parseErrors[0], &validationErr) {
+		t.Fatalf("Expected *models.ValidationError, got %T", parseErrors[0])
+	}
+	if validationErr.Sheet != "Funcionários" || validationErr.RowNumber != 5 ||
+		validationErr.Column != "C" || validationErr.Field != csvreader.ColumnAge {
+		t.Errorf("Unexpected error location: %+v", validationErr)
+	}
+	if !strings.Contains(validationErr.Error(), "Planilha 'Funcionários', Linha 5, Coluna C") {
+		t.Errorf("Expected location in message, got %q", validationErr.Error())
+	}
+}
+
+func TestReadAll_AllFieldErrorsInRow(t *testing.T) {
+	parts := testParts()
+	parts["xl/worksheets/sheet1.xml"] = `<worksheet><sheetData>
+		<row r="1">
+			<c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="C1" t="s"><v>2</v></c>
+			<c r="D1" t="s"><v>3</v></c><c r="E1" t="s"><v>4</v></c><c r="F1" t="s"><v>5</v></c>
+			<c r="G1" t="s"><v>6</v></c>
+		</row>
+		<row r="2">
+			<c r="B2" t="inlineStr"><is><t>joao@empresa.com</t></is></c><c r="C2"><v>28</v></c>
+			<c r="D2"><v>-1</v></c><c r="E2" t="s"><v>8</v></c><c r="F2" t="b"><v>1</v></c>
+			<c r="G2" s="1"><v>45306</v></c>
+		</row>
+	</sheetData></worksheet>`
+	path := createTestXLSX(t, parts)
+
+	_, parseErrors, err := NewReader(path).ReadAll()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(parseErrors) != 1 {
+		t.Fatalf("Expected 1 parse error, got %v", parseErrors)
+	}
+
+	validationErrs := models.ValidationErrorsOf(parseErrors[0])
+	if len(validationErrs) != 2 {
+		t.Fatalf("Expected 2 field errors, got %v", validationErrs)
+	}
+	if validationErrs[0].Column != "A" || validationErrs[0].Field != csvreader.ColumnName ||
+		validationErrs[1].Column != "D" || validationErrs[1].Field != csvreader.ColumnSalary {
+		t.Errorf("Unexpected field errors: %v", validationErrs)
+	}
+}
+
+func TestOpen_FirstSheetByDefault(t *testing.T) {
+	path := createTestXLSX(t, testParts())
+
+	// A primeira planilha (Resumo) está vazia
+	if _, err := NewReader(path).Open(); err == nil || !strings.Contains(err.Error(), "vazia") {
+		t.Errorf("Expected empty sheet error, got %v", err)
+	}
+}
+
+func TestOpen_SheetNotFound(t *testing.T) {
+	path := createTestXLSX(t, testParts())
+
+	if _, err := NewReaderWithOptions(path, Options{Sheet: "Inexistente"}).Open(); !errors.Is(err, ErrSheetNotFound) {
+		t.Errorf("Expected ErrSheetNotFound, got %v", err)
+	}
+}
+
+func TestOpen_MissingColumns(t *testing.T) {
+	parts := testParts()
+	parts["xl/worksheets/sheet1.xml"] = `<worksheet><sheetData><row r="1">
+		<c r="A1" t="inlineStr"><is><t>name</t></is></c></row></sheetData></worksheet>`
+	path := createTestXLSX(t, parts)
+
+	if _, err := NewReader(path).Open(); !errors.Is(err, csvreader.ErrMissingColumns) {
+		t.Errorf("Expected ErrMissingColumns, got %v", err)
+	}
+}
+
+func TestOpen_InvalidFile(t *testing.T) {
+	path := createTestXLSX(t, map[string]string{"foo.txt": "bar"})
+
+	if _, err := NewReader(path).Open(); !errors.Is(err, ErrInvalidFile) {
+		t.Errorf("Expected ErrInvalidFile, got %v", err)
+	}
+}
+
+func TestExcelDate(t *testing.T) {
+	tests := []struct {
+		serial   float64
+		date1904 bool
+		expected time.Time
+	}{
+		{45306, false, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{45306.5, false, time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)},
+		{43844, true, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		if got := excelDate(tt.serial, tt.date1904); !got.Equal(tt.expected) {
+			t.Errorf("excelDate(%v, %v) = %v, expected %v", tt.serial, tt.date1904, got, tt.expected)
+		}
+	}
+}
+
+func TestIsDateFormat(t *testing.T) {
+	tests := []struct {
+		code     string
+		expected bool
+	}{
+		{"dd/mm/yyyy", true},
+		{"yyyy-mm-dd hh:mm", true},
+		{"#,##0.00", false},
+		{`0.00 "dias"`, false},
+		{"[Red]0.00", false},
+		{"[h]:mm", true},
+		{"[mm]", true},
+		{"[ss].00", true},
+		{"[$-416]0.00", false},
+		{"[Magenta]#,##0", false},
+	}
+
+	for _, tt := range tests {
+		if got := isDateFormat(tt.code); got != tt.expected {
+			t.Errorf("isDateFormat(%q) = %v, expected %v", tt.code, got, tt.expected)
+		}
+	}
+}
+
+func TestColumnRefs(t *testing.T) {
+	for _, name := range []string{"A", "Z", "AA", "AZ", "BA", "XFD"} {
+		letters, index := splitRef(name + "12")
+		if letters != name || columnName(index) != name {
+			t.Errorf("Expected round trip for %s, got %s (index %d)", name, columnName(index), index)
+		}
+	}
+}
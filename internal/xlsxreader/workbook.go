@@ -0,0 +1,417 @@
+package xlsxreader
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrSheetNotFound = errors.New("planilha não encontrada")
+	ErrInvalidFile   = errors.New("arquivo XLSX inválido")
+)
+
+// cellKind é o tipo de valor de uma célula
+type cellKind int
+
+const (
+	cellEmpty cellKind = iota
+	cellText
+	cellNumber
+	cellBool
+	cellDate
+	cellError
+)
+
+// cell é o valor de uma célula já convertido conforme seu tipo e estilo
+type cell struct {
+	kind   cellKind
+	text   string
+	number float64
+	date   time.Time
+}
+
+// String retorna o valor da célula como texto, para mensagens e colunas de texto
+func (c cell) String() string {
+	switch c.kind {
+	case cellNumber:
+		return strconv.FormatFloat(c.number, 'f', -1, 64)
+	case cellBool:
+		return strconv.FormatBool(c.number != 0)
+	case cellDate:
+		return c.date.Format("2006-01-02")
+	default:
+		return c.text
+	}
+}
+
+// workbook guarda as partes do arquivo necessárias para ler uma planilha
+type workbook struct {
+	zip           *zip.ReadCloser
+	sheets        []sheetRef
+	sharedStrings []string
+	// dateStyles indica, por índice de estilo (atributo s), se o número é uma data
+	dateStyles []bool
+	date1904   bool
+}
+
+// sheetRef associa o nome de uma planilha ao arquivo que a contém
+type sheetRef struct {
+	name string
+	path string
+}
+
+// openWorkbook abre o arquivo e lê o índice de planilhas, as strings compartilhadas e os estilos
+func openWorkbook(filePath string) (*workbook, error) {
+	archive, err := zip.OpenReader(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao abrir arquivo: %w", err)
+	}
+
+	wb := &workbook{zip: archive}
+	if err := wb.load(); err != nil {
+		archive.Close()
+		return nil, err
+	}
+	return wb, nil
+}
+
+// load lê as partes do workbook
+func (wb *workbook) load() error {
+	var book struct {
+		Properties struct {
+			Date1904 bool `xml:"date1904,attr"`
+		} `xml:"workbookPr"`
+		Sheets []struct {
+			Name  string     `xml:"name,attr"`
+			Attrs []xml.Attr `xml:",any,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	if err := wb.decodePart("xl/workbook.xml", &book, true); err != nil {
+		return err
+	}
+	wb.date1904 = book.Properties.Date1904
+
+	var rels struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	if err := wb.decodePart("xl/_rels/workbook.xml.rels", &rels, true); err != nil {
+		return err
+	}
+	targets := make(map[string]string, len(rels.Relationships))
+	for _, rel := range rels.Relationships {
+		target := rel.Target
+		if strings.HasPrefix(target, "/") {
+			target = strings.TrimPrefix(target, "/")
+		} else {
+			target = path.Join("xl", target)
+		}
+		targets[rel.ID] = target
+	}
+
+	for _, sheet := range book.Sheets {
+		// O atributo r:id aponta para a relação com o arquivo da planilha
+		for _, attr := range sheet.Attrs {
+			if attr.Name.Local == "id" && attr.Name.Space != "" {
+				wb.sheets = append(wb.sheets, sheetRef{name: sheet.Name, path: targets[attr.Value]})
+			}
+		}
+	}
+
+	var shared struct {
+		Items []richText `xml:"si"`
+	}
+	if err := wb.decodePart("xl/sharedStrings.xml", &shared, false); err != nil {
+		return err
+	}
+	for _, item := range shared.Items {
+		wb.sharedStrings = append(wb.sharedStrings, item.String())
+	}
+
+	var styles struct {
+		NumFmts []struct {
+			ID   int    `xml:"numFmtId,attr"`
+			Code string `xml:"formatCode,attr"`
+		} `xml:"numFmts>numFmt"`
+		CellXfs []struct {
+			NumFmtID int `xml:"numFmtId,attr"`
+		} `xml:"cellXfs>xf"`
+	}
+	if err := wb.decodePart("xl/styles.xml", &styles, false); err != nil {
+		return err
+	}
+	customDates := make(map[int]bool, len(styles.NumFmts))
+	for _, format := range styles.NumFmts {
+		customDates[format.ID] = isDateFormat(format.Code)
+	}
+	for _, xf := range styles.CellXfs {
+		isDate, custom := customDates[xf.NumFmtID]
+		wb.dateStyles = append(wb.dateStyles, isDate || (!custom && isBuiltinDateFormat(xf.NumFmtID)))
+	}
+
+	return nil
+}
+
+// decodePart decodifica uma parte XML do arquivo. Partes opcionais ausentes são ignoradas.
+func (wb *workbook) decodePart(name string, v interface{}, required bool) error {
+	file := wb.find(name)
+	if file == nil {
+		if required {
+			return fmt.Errorf("%w: %s ausente", ErrInvalidFile, name)
+		}
+		return nil
+	}
+
+	part, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+	defer part.Close()
+
+	if err := xml.NewDecoder(part).Decode(v); err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrInvalidFile, name, err)
+	}
+	return nil
+}
+
+// find localiza uma parte do arquivo pelo nome
+func (wb *workbook) find(name string) *zip.File {
+	for _, file := range wb.zip.File {
+		if file.Name == name {
+			return file
+		}
+	}
+	return nil
+}
+
+// sheet localiza a planilha pelo nome; nome vazio seleciona a primeira
+func (wb *workbook) sheet(name string) (sheetRef, error) {
+	for _, sheet := range wb.sheets {
+		if name == "" || strings.EqualFold(sheet.name, name) {
+			return sheet, nil
+		}
+	}
+	if name == "" {
+		return sheetRef{}, fmt.Errorf("%w: o arquivo não tem planilhas", ErrSheetNotFound)
+	}
+	return sheetRef{}, fmt.Errorf("%w: %s", ErrSheetNotFound, name)
+}
+
+// Close fecha o arquivo
+func (wb *workbook) Close() error {
+	return wb.zip.Close()
+}
+
+// richText é um texto que pode vir inteiro (<t>) ou em trechos formatados (<r><t>)
+type richText struct {
+	Text string   `xml:"t"`
+	Runs []string `xml:"r>t"`
+}
+
+func (t richText) String() string {
+	return t.Text + strings.Join(t.Runs, "")
+}
+
+// rawCell é uma célula como aparece no XML da planilha
+type rawCell struct {
+	Ref    string   `xml:"r,attr"`
+	Style  int      `xml:"s,attr"`
+	Type   string   `xml:"t,attr"`
+	Value  string   `xml:"v"`
+	Inline richText `xml:"is"`
+}
+
+// convert interpreta a célula conforme o tipo declarado e o estilo
+func (wb *workbook) convert(raw rawCell) (cell, error) {
+	switch raw.Type {
+	case "s":
+		index, err := strconv.Atoi(raw.Value)
+		if err != nil || index < 0 || index >= len(wb.sharedStrings) {
+			return cell{}, fmt.Errorf("%w: string compartilhada %q inexistente", ErrInvalidFile, raw.Value)
+		}
+		return textCell(wb.sharedStrings[index]), nil
+	case "inlineStr":
+		return textCell(raw.Inline.String()), nil
+	case "str":
+		return textCell(raw.Value), nil
+	case "b":
+		return cell{kind: cellBool, number: boolNumber(raw.Value == "1")}, nil
+	case "e":
+		return cell{kind: cellError, text: raw.Value}, nil
+	case "d":
+		date, err := time.Parse("2006-01-02T15:04:05", strings.TrimSuffix(raw.Value, "Z"))
+		if err != nil {
+			if date, err = time.Parse("2006-01-02", raw.Value); err != nil {
+				return textCell(raw.Value), nil
+			}
+		}
+		return cell{kind: cellDate, date: date}, nil
+	}
+
+	// Tipo "n" ou ausente: número, que pode ser uma data conforme o estilo
+	if raw.Value == "" {
+		return cell{}, nil
+	}
+	number, err := strconv.ParseFloat(raw.Value, 64)
+	if err != nil {
+		return cell{}, fmt.Errorf("%w: número inválido %q", ErrInvalidFile, raw.Value)
+	}
+	if raw.Style >= 0 && raw.Style < len(wb.dateStyles) && wb.dateStyles[raw.Style] {
+		return cell{kind: cellDate, date: excelDate(number, wb.date1904), number: number}, nil
+	}
+	return cell{kind: cellNumber, number: number}, nil
+}
+
+// textCell cria uma célula de texto; texto vazio é célula vazia
+func textCell(text string) cell {
+	if text == "" {
+		return cell{}
+	}
+	return cell{kind: cellText, text: text}
+}
+
+func boolNumber(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// excelDate converte um número serial do Excel em data (UTC)
+func excelDate(serial float64, date1904 bool) time.Time {
+	// No sistema 1900 o dia 0 é 1899-12-30, compensando o 29/02/1900 inexistente que o Excel conta
+	epoch := time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+	if date1904 {
+		epoch = time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	days := math.Floor(serial)
+	fraction := time.Duration(math.Round((serial - days) * 24 * float64(time.Hour) / float64(time.Second)))
+	return epoch.AddDate(0, 0, int(days)).Add(fraction * time.Second)
+}
+
+// isBuiltinDateFormat indica se um formato numérico embutido do Excel representa data ou hora
+func isBuiltinDateFormat(id int) bool {
+	return (id >= 14 && id <= 22) || (id >= 27 && id <= 36) || (id >= 45 && id <= 47) || (id >= 50 && id <= 58)
+}
+
+// isDateFormat indica se um formato personalizado representa data ou hora,
+// ignorando texto literal entre aspas e seções entre colchetes (cores,
+// localidade), exceto as de tempo decorrido, como [h]:mm e [mm]:ss
+func isDateFormat(code string) bool {
+	inQuotes, inBrackets := false, false
+	var bracket strings.Builder
+	for _, c := range strings.ToLower(code) {
+		switch {
+		case c == '"':
+			inQuotes = !inQuotes
+		case inQuotes:
+		case c == '[':
+			inBrackets = true
+			bracket.Reset()
+		case c == ']':
+			inBrackets = false
+			if isElapsedTime(bracket.String()) {
+				return true
+			}
+		case inBrackets:
+			bracket.WriteRune(c)
+		case c == 'y' || c == 'd' || c == 'h' || c == 's':
+			return true
+		}
+	}
+	return false
+}
+
+// isElapsedTime indica se a seção entre colchetes é um código de tempo
+// decorrido: uma única letra h, m ou s, repetida ([h], [mm], [ss])
+func isElapsedTime(section string) bool {
+	if section == "" || strings.Trim(section, section[:1]) != "" {
+		return false
+	}
+	return strings.Contains("hms", section[:1])
+}
+
+// splitRef separa uma referência de célula ("C12") na coluna ("C") e seu índice, a partir de zero
+func splitRef(ref string) (string, int) {
+	letters := strings.TrimRightFunc(ref, func(r rune) bool { return r >= '0' && r <= '9' })
+	index := 0
+	for _, c := range strings.ToUpper(letters) {
+		if c < 'A' || c > 'Z' {
+			return "", -1
+		}
+		index = index*26 + int(c-'A'+1)
+	}
+	return letters, index - 1
+}
+
+// columnName converte o índice da coluna, a partir de zero, em letras ("A", "AB")
+func columnName(index int) string {
+	name := ""
+	for index++; index > 0; index = (index - 1) / 26 {
+		name = string(rune('A'+(index-1)%26)) + name
+	}
+	return name
+}
+
+// rowDecoder lê as linhas de uma planilha uma a uma, sem carregar o XML inteiro
+type rowDecoder struct {
+	part    io.ReadCloser
+	decoder *xml.Decoder
+}
+
+// openRows abre o XML da planilha para leitura das linhas
+func (wb *workbook) openRows(sheet sheetRef) (*rowDecoder, error) {
+	file := wb.find(sheet.path)
+	if file == nil {
+		return nil, fmt.Errorf("%w: planilha %s ausente", ErrInvalidFile, sheet.name)
+	}
+	part, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+	return &rowDecoder{part: part, decoder: xml.NewDecoder(part)}, nil
+}
+
+// rawRow é uma linha como aparece no XML da planilha
+type rawRow struct {
+	Number int       `xml:"r,attr"`
+	Cells  []rawCell `xml:"c"`
+}
+
+// next retorna a próxima linha da planilha, ou io.EOF ao final
+func (d *rowDecoder) next() (rawRow, error) {
+	for {
+		token, err := d.decoder.Token()
+		if err != nil {
+			if err == io.EOF {
+				return rawRow{}, io.EOF
+			}
+			return rawRow{}, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "row" {
+			continue
+		}
+
+		var row rawRow
+		if err := d.decoder.DecodeElement(&row, &start); err != nil {
+			return rawRow{}, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+		}
+		return row, nil
+	}
+}
+
+func (d *rowDecoder) Close() error {
+	return d.part.Close()
+}